@@ -11,6 +11,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
@@ -24,6 +26,121 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MenuUpdateKind int32
+
+const (
+	MenuUpdateKind_MENU_UPDATE_KIND_UNSPECIFIED MenuUpdateKind = 0
+	// Live pizza or category of the snapshot
+	MenuUpdateKind_MENU_SNAPSHOT MenuUpdateKind = 1
+	// Ends the snapshot, the watcher replaces its menu with the snapshot
+	MenuUpdateKind_MENU_SNAPSHOT_END MenuUpdateKind = 2
+	MenuUpdateKind_MENU_CREATED      MenuUpdateKind = 3
+	MenuUpdateKind_MENU_UPDATED      MenuUpdateKind = 4
+	// Carries the pizza or the category as it was before the removal
+	MenuUpdateKind_MENU_DELETED MenuUpdateKind = 5
+)
+
+// Enum value maps for MenuUpdateKind.
+var (
+	MenuUpdateKind_name = map[int32]string{
+		0: "MENU_UPDATE_KIND_UNSPECIFIED",
+		1: "MENU_SNAPSHOT",
+		2: "MENU_SNAPSHOT_END",
+		3: "MENU_CREATED",
+		4: "MENU_UPDATED",
+		5: "MENU_DELETED",
+	}
+	MenuUpdateKind_value = map[string]int32{
+		"MENU_UPDATE_KIND_UNSPECIFIED": 0,
+		"MENU_SNAPSHOT":                1,
+		"MENU_SNAPSHOT_END":            2,
+		"MENU_CREATED":                 3,
+		"MENU_UPDATED":                 4,
+		"MENU_DELETED":                 5,
+	}
+)
+
+func (x MenuUpdateKind) Enum() *MenuUpdateKind {
+	p := new(MenuUpdateKind)
+	*p = x
+	return p
+}
+
+func (x MenuUpdateKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuUpdateKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pizzaland_pizzaland_proto_enumTypes[0].Descriptor()
+}
+
+func (MenuUpdateKind) Type() protoreflect.EnumType {
+	return &file_pizzaland_pizzaland_proto_enumTypes[0]
+}
+
+func (x MenuUpdateKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuUpdateKind.Descriptor instead.
+func (MenuUpdateKind) EnumDescriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{0}
+}
+
+type MenuFileFormat int32
+
+const (
+	MenuFileFormat_MENU_FILE_FORMAT_UNSPECIFIED MenuFileFormat = 0
+	// Header row with the kind, category, name, description, type_dough, price and diameter columns
+	MenuFileFormat_MENU_FILE_CSV MenuFileFormat = 1
+	// List of the objects with the keys of the CSV columns
+	MenuFileFormat_MENU_FILE_JSON MenuFileFormat = 2
+	MenuFileFormat_MENU_FILE_YAML MenuFileFormat = 3
+)
+
+// Enum value maps for MenuFileFormat.
+var (
+	MenuFileFormat_name = map[int32]string{
+		0: "MENU_FILE_FORMAT_UNSPECIFIED",
+		1: "MENU_FILE_CSV",
+		2: "MENU_FILE_JSON",
+		3: "MENU_FILE_YAML",
+	}
+	MenuFileFormat_value = map[string]int32{
+		"MENU_FILE_FORMAT_UNSPECIFIED": 0,
+		"MENU_FILE_CSV":                1,
+		"MENU_FILE_JSON":               2,
+		"MENU_FILE_YAML":               3,
+	}
+)
+
+func (x MenuFileFormat) Enum() *MenuFileFormat {
+	p := new(MenuFileFormat)
+	*p = x
+	return p
+}
+
+func (x MenuFileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuFileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_pizzaland_pizzaland_proto_enumTypes[1].Descriptor()
+}
+
+func (MenuFileFormat) Type() protoreflect.EnumType {
+	return &file_pizzaland_pizzaland_proto_enumTypes[1]
+}
+
+func (x MenuFileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuFileFormat.Descriptor instead.
+func (MenuFileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{1}
+}
+
 type TypeDough int32
 
 const (
@@ -57,11 +174,11 @@ func (x TypeDough) String() string {
 }
 
 func (TypeDough) Descriptor() protoreflect.EnumDescriptor {
-	return file_pizzaland_pizzaland_proto_enumTypes[0].Descriptor()
+	return file_pizzaland_pizzaland_proto_enumTypes[2].Descriptor()
 }
 
 func (TypeDough) Type() protoreflect.EnumType {
-	return &file_pizzaland_pizzaland_proto_enumTypes[0]
+	return &file_pizzaland_pizzaland_proto_enumTypes[2]
 }
 
 func (x TypeDough) Number() protoreflect.EnumNumber {
@@ -70,14 +187,16 @@ func (x TypeDough) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TypeDough.Descriptor instead.
 func (TypeDough) EnumDescriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{0}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{2}
 }
 
 type SaveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pizza         *PizzaProperties       `protobuf:"bytes,1,opt,name=pizza,proto3" json:"pizza,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pizza *PizzaProperties       `protobuf:"bytes,1,opt,name=pizza,proto3" json:"pizza,omitempty"`
+	// Replays with the same key return the original response, also accepted as the idempotency-key metadata
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SaveRequest) Reset() {
@@ -117,6 +236,13 @@ func (x *SaveRequest) GetPizza() *PizzaProperties {
 	return nil
 }
 
+func (x *SaveRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type SaveResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PizzaId       uint64                 `protobuf:"varint,1,opt,name=pizza_id,json=pizzaId,proto3" json:"pizza_id,omitempty"`
@@ -400,13 +526,16 @@ func (x *ListResponse) GetPizza() []*PizzaProperties {
 }
 
 type UpdateRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	CategoryId    *wrapperspb.UInt32Value `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
-	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	TypeDough     *TypeDough              `protobuf:"varint,4,opt,name=type_dough,json=typeDough,proto3,enum=github.nhassl3.pizzaland.PizzaLand.TypeDough,oneof" json:"type_dough,omitempty"`
-	Price         *wrapperspb.FloatValue  `protobuf:"bytes,5,opt,name=price,proto3,oneof" json:"price,omitempty"`
-	Diameter      *wrapperspb.UInt32Value `protobuf:"bytes,6,opt,name=diameter,proto3,oneof" json:"diameter,omitempty"`
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	CategoryId  *wrapperspb.UInt32Value `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Name        *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	TypeDough   *TypeDough              `protobuf:"varint,4,opt,name=type_dough,json=typeDough,proto3,enum=github.nhassl3.pizzaland.PizzaLand.TypeDough,oneof" json:"type_dough,omitempty"`
+	Price       *wrapperspb.FloatValue  `protobuf:"bytes,5,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	Diameter    *wrapperspb.UInt32Value `protobuf:"bytes,6,opt,name=diameter,proto3,oneof" json:"diameter,omitempty"`
+	PizzaId     uint64                  `protobuf:"varint,7,opt,name=pizza_id,json=pizzaId,proto3" json:"pizza_id,omitempty"`
+	// Expected version of the pizza, zero skips the check
+	Version       uint64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -483,6 +612,20 @@ func (x *UpdateRequest) GetDiameter() *wrapperspb.UInt32Value {
 	return nil
 }
 
+func (x *UpdateRequest) GetPizzaId() uint64 {
+	if x != nil {
+		return x.PizzaId
+	}
+	return 0
+}
+
+func (x *UpdateRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type UpdateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -527,20 +670,139 @@ func (x *UpdateResponse) GetSuccess() bool {
 	return false
 }
 
+// Follows AIP-134: only the fields listed in update_mask are written.
+// Missing mask means every populated field, "*" replaces every field.
+// A masked field left unset in the pizza is cleared.
+type UpdatePizzaRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	PizzaId uint64                 `protobuf:"varint,1,opt,name=pizza_id,json=pizzaId,proto3" json:"pizza_id,omitempty"`
+	// Validated after the update mask is applied to the stored pizza
+	Pizza      *PizzaProperties       `protobuf:"bytes,2,opt,name=pizza,proto3" json:"pizza,omitempty"`
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Expected version of the pizza, zero skips the check
+	Version       uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePizzaRequest) Reset() {
+	*x = UpdatePizzaRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePizzaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePizzaRequest) ProtoMessage() {}
+
+func (x *UpdatePizzaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePizzaRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePizzaRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdatePizzaRequest) GetPizzaId() uint64 {
+	if x != nil {
+		return x.PizzaId
+	}
+	return 0
+}
+
+func (x *UpdatePizzaRequest) GetPizza() *PizzaProperties {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+func (x *UpdatePizzaRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdatePizzaRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdatePizzaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pizza         *PizzaProperties       `protobuf:"bytes,1,opt,name=pizza,proto3" json:"pizza,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePizzaResponse) Reset() {
+	*x = UpdatePizzaResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePizzaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePizzaResponse) ProtoMessage() {}
+
+func (x *UpdatePizzaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePizzaResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePizzaResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdatePizzaResponse) GetPizza() *PizzaProperties {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
 type RemoveRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Identifier:
 	//
 	//	*RemoveRequest_PizzaId
 	//	*RemoveRequest_PizzaName
-	Identifier    isRemoveRequest_Identifier `protobuf_oneof:"identifier"`
+	Identifier isRemoveRequest_Identifier `protobuf_oneof:"identifier"`
+	// Expected version of the pizza, zero skips the check
+	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RemoveRequest) Reset() {
 	*x = RemoveRequest{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[8]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +814,7 @@ func (x *RemoveRequest) String() string {
 func (*RemoveRequest) ProtoMessage() {}
 
 func (x *RemoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[8]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +827,7 @@ func (x *RemoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveRequest.ProtoReflect.Descriptor instead.
 func (*RemoveRequest) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{8}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RemoveRequest) GetIdentifier() isRemoveRequest_Identifier {
@@ -593,6 +855,13 @@ func (x *RemoveRequest) GetPizzaName() string {
 	return ""
 }
 
+func (x *RemoveRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type isRemoveRequest_Identifier interface {
 	isRemoveRequest_Identifier()
 }
@@ -618,7 +887,7 @@ type RemoveResponse struct {
 
 func (x *RemoveResponse) Reset() {
 	*x = RemoveResponse{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[9]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -630,7 +899,7 @@ func (x *RemoveResponse) String() string {
 func (*RemoveResponse) ProtoMessage() {}
 
 func (x *RemoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[9]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,7 +912,7 @@ func (x *RemoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveResponse.ProtoReflect.Descriptor instead.
 func (*RemoveResponse) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{9}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RemoveResponse) GetSuccess() bool {
@@ -654,15 +923,17 @@ func (x *RemoveResponse) GetSuccess() bool {
 }
 
 type SaveCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      *CategoryProperties    `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category *CategoryProperties    `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Replays with the same key return the original response, also accepted as the idempotency-key metadata
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SaveCategoryRequest) Reset() {
 	*x = SaveCategoryRequest{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[10]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -674,7 +945,7 @@ func (x *SaveCategoryRequest) String() string {
 func (*SaveCategoryRequest) ProtoMessage() {}
 
 func (x *SaveCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[10]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -687,7 +958,7 @@ func (x *SaveCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveCategoryRequest.ProtoReflect.Descriptor instead.
 func (*SaveCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{10}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SaveCategoryRequest) GetCategory() *CategoryProperties {
@@ -697,6 +968,13 @@ func (x *SaveCategoryRequest) GetCategory() *CategoryProperties {
 	return nil
 }
 
+func (x *SaveCategoryRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type SaveCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CategoryId    uint32                 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
@@ -706,7 +984,7 @@ type SaveCategoryResponse struct {
 
 func (x *SaveCategoryResponse) Reset() {
 	*x = SaveCategoryResponse{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[11]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -718,7 +996,7 @@ func (x *SaveCategoryResponse) String() string {
 func (*SaveCategoryResponse) ProtoMessage() {}
 
 func (x *SaveCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[11]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -731,7 +1009,7 @@ func (x *SaveCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveCategoryResponse.ProtoReflect.Descriptor instead.
 func (*SaveCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{11}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SaveCategoryResponse) GetCategoryId() uint32 {
@@ -754,7 +1032,7 @@ type GetCategoryRequest struct {
 
 func (x *GetCategoryRequest) Reset() {
 	*x = GetCategoryRequest{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[12]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -766,7 +1044,7 @@ func (x *GetCategoryRequest) String() string {
 func (*GetCategoryRequest) ProtoMessage() {}
 
 func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[12]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -779,7 +1057,7 @@ func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
 func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{12}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetCategoryRequest) GetIdentifier() isGetCategoryRequest_Identifier {
@@ -832,7 +1110,7 @@ type GetCategoryResponse struct {
 
 func (x *GetCategoryResponse) Reset() {
 	*x = GetCategoryResponse{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[13]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -844,7 +1122,7 @@ func (x *GetCategoryResponse) String() string {
 func (*GetCategoryResponse) ProtoMessage() {}
 
 func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[13]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -857,7 +1135,7 @@ func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
 func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{13}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetCategoryResponse) GetPizza() *ListResponse {
@@ -868,16 +1146,19 @@ func (x *GetCategoryResponse) GetPizza() *ListResponse {
 }
 
 type UpdateCategoryRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Name          *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	Name        *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	CategoryId  uint32                  `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Expected version of the category, zero skips the check
+	Version       uint64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCategoryRequest) Reset() {
 	*x = UpdateCategoryRequest{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[14]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -889,7 +1170,7 @@ func (x *UpdateCategoryRequest) String() string {
 func (*UpdateCategoryRequest) ProtoMessage() {}
 
 func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[14]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,7 +1183,7 @@ func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{14}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateCategoryRequest) GetName() *wrapperspb.StringValue {
@@ -919,6 +1200,20 @@ func (x *UpdateCategoryRequest) GetDescription() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *UpdateCategoryRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type UpdateCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -928,7 +1223,7 @@ type UpdateCategoryResponse struct {
 
 func (x *UpdateCategoryResponse) Reset() {
 	*x = UpdateCategoryResponse{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[15]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -940,7 +1235,7 @@ func (x *UpdateCategoryResponse) String() string {
 func (*UpdateCategoryResponse) ProtoMessage() {}
 
 func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[15]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -953,7 +1248,7 @@ func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{15}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UpdateCategoryResponse) GetSuccess() bool {
@@ -969,14 +1264,16 @@ type RemoveCategoryRequest struct {
 	//
 	//	*RemoveCategoryRequest_CategoryId
 	//	*RemoveCategoryRequest_CategoryName
-	Identifier    isRemoveCategoryRequest_Identifier `protobuf_oneof:"identifier"`
+	Identifier isRemoveCategoryRequest_Identifier `protobuf_oneof:"identifier"`
+	// Expected version of the category, zero skips the check
+	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RemoveCategoryRequest) Reset() {
 	*x = RemoveCategoryRequest{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[16]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -988,7 +1285,7 @@ func (x *RemoveCategoryRequest) String() string {
 func (*RemoveCategoryRequest) ProtoMessage() {}
 
 func (x *RemoveCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[16]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1298,7 @@ func (x *RemoveCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveCategoryRequest.ProtoReflect.Descriptor instead.
 func (*RemoveCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{16}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RemoveCategoryRequest) GetIdentifier() isRemoveCategoryRequest_Identifier {
@@ -1029,6 +1326,13 @@ func (x *RemoveCategoryRequest) GetCategoryName() string {
 	return ""
 }
 
+func (x *RemoveCategoryRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type isRemoveCategoryRequest_Identifier interface {
 	isRemoveCategoryRequest_Identifier()
 }
@@ -1054,7 +1358,7 @@ type RemoveCategoryResponse struct {
 
 func (x *RemoveCategoryResponse) Reset() {
 	*x = RemoveCategoryResponse{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[17]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1066,7 +1370,7 @@ func (x *RemoveCategoryResponse) String() string {
 func (*RemoveCategoryResponse) ProtoMessage() {}
 
 func (x *RemoveCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[17]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1079,7 +1383,7 @@ func (x *RemoveCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveCategoryResponse.ProtoReflect.Descriptor instead.
 func (*RemoveCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{17}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RemoveCategoryResponse) GetSuccess() bool {
@@ -1089,23 +1393,2767 @@ func (x *RemoveCategoryResponse) GetSuccess() bool {
 	return false
 }
 
-// Composite structure for pizza properties
-type PizzaProperties struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	PizzaId       *wrapperspb.UInt64Value `protobuf:"bytes,1,opt,name=pizza_id,json=pizzaId,proto3,oneof" json:"pizza_id,omitempty"`
-	CategoryId    uint32                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Name          string                  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	TypeDough     TypeDough               `protobuf:"varint,5,opt,name=type_dough,json=typeDough,proto3,enum=github.nhassl3.pizzaland.PizzaLand.TypeDough" json:"type_dough,omitempty"`
-	Price         float32                 `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
-	Diameter      uint32                  `protobuf:"varint,7,opt,name=diameter,proto3" json:"diameter,omitempty"`
+type RestoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PizzaId       uint64                 `protobuf:"varint,1,opt,name=pizza_id,json=pizzaId,proto3" json:"pizza_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreRequest) GetPizzaId() uint64 {
+	if x != nil {
+		return x.PizzaId
+	}
+	return 0
+}
+
+type RestoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResponse) ProtoMessage() {}
+
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestoreResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListDeletedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        uint32                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedRequest) Reset() {
+	*x = ListDeletedRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedRequest) ProtoMessage() {}
+
+func (x *ListDeletedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListDeletedRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDeletedRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeletedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pizza         []*PizzaProperties     `protobuf:"bytes,1,rep,name=pizza,proto3" json:"pizza,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedResponse) Reset() {
+	*x = ListDeletedResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedResponse) ProtoMessage() {}
+
+func (x *ListDeletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListDeletedResponse) GetPizza() []*PizzaProperties {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+type RestoreCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    uint32                 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryRequest) Reset() {
+	*x = RestoreCategoryRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryRequest) ProtoMessage() {}
+
+func (x *RestoreCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RestoreCategoryRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+type RestoreCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCategoryResponse) Reset() {
+	*x = RestoreCategoryResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCategoryResponse) ProtoMessage() {}
+
+func (x *RestoreCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCategoryResponse.ProtoReflect.Descriptor instead.
+func (*RestoreCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestoreCategoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListDeletedCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        uint32                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedCategoriesRequest) Reset() {
+	*x = ListDeletedCategoriesRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedCategoriesRequest) ProtoMessage() {}
+
+func (x *ListDeletedCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListDeletedCategoriesRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListDeletedCategoriesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeletedCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      []*CategoryProperties  `protobuf:"bytes,1,rep,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedCategoriesResponse) Reset() {
+	*x = ListDeletedCategoriesResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedCategoriesResponse) ProtoMessage() {}
+
+func (x *ListDeletedCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListDeletedCategoriesResponse) GetCategory() []*CategoryProperties {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// Batch requests run in a single transaction.
+// By default the batch is all-or-nothing and fails with the error of the first failed item.
+// With best_effort every item is applied on its own and the result is reported per item.
+// With validate_only the batch is checked against every rule but nothing is written.
+type BatchSaveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every pizza is validated on its own, see BatchItemResult
+	Pizza         []*PizzaProperties `protobuf:"bytes,1,rep,name=pizza,proto3" json:"pizza,omitempty"`
+	BestEffort    bool               `protobuf:"varint,2,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	ValidateOnly  bool               `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSaveRequest) Reset() {
+	*x = BatchSaveRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSaveRequest) ProtoMessage() {}
+
+func (x *BatchSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSaveRequest.ProtoReflect.Descriptor instead.
+func (*BatchSaveRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchSaveRequest) GetPizza() []*PizzaProperties {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+func (x *BatchSaveRequest) GetBestEffort() bool {
+	if x != nil {
+		return x.BestEffort
+	}
+	return false
+}
+
+func (x *BatchSaveRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type BatchSaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []*BatchItemResult     `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSaveResponse) Reset() {
+	*x = BatchSaveResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSaveResponse) ProtoMessage() {}
+
+func (x *BatchSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSaveResponse.ProtoReflect.Descriptor instead.
+func (*BatchSaveResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BatchSaveResponse) GetResult() []*BatchItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type BatchUpdateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every update is validated on its own, see BatchItemResult
+	Pizza         []*UpdatePizzaRequest `protobuf:"bytes,1,rep,name=pizza,proto3" json:"pizza,omitempty"`
+	BestEffort    bool                  `protobuf:"varint,2,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	ValidateOnly  bool                  `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateRequest) Reset() {
+	*x = BatchUpdateRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateRequest) ProtoMessage() {}
+
+func (x *BatchUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BatchUpdateRequest) GetPizza() []*UpdatePizzaRequest {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+func (x *BatchUpdateRequest) GetBestEffort() bool {
+	if x != nil {
+		return x.BestEffort
+	}
+	return false
+}
+
+func (x *BatchUpdateRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type BatchUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []*BatchItemResult     `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateResponse) Reset() {
+	*x = BatchUpdateResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateResponse) ProtoMessage() {}
+
+func (x *BatchUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BatchUpdateResponse) GetResult() []*BatchItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type BatchRemoveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every removal is validated on its own, see BatchItemResult
+	Pizza         []*RemoveRequest `protobuf:"bytes,1,rep,name=pizza,proto3" json:"pizza,omitempty"`
+	BestEffort    bool             `protobuf:"varint,2,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	ValidateOnly  bool             `protobuf:"varint,3,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRemoveRequest) Reset() {
+	*x = BatchRemoveRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRemoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRemoveRequest) ProtoMessage() {}
+
+func (x *BatchRemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRemoveRequest.ProtoReflect.Descriptor instead.
+func (*BatchRemoveRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BatchRemoveRequest) GetPizza() []*RemoveRequest {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+func (x *BatchRemoveRequest) GetBestEffort() bool {
+	if x != nil {
+		return x.BestEffort
+	}
+	return false
+}
+
+func (x *BatchRemoveRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type BatchRemoveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        []*BatchItemResult     `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRemoveResponse) Reset() {
+	*x = BatchRemoveResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRemoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRemoveResponse) ProtoMessage() {}
+
+func (x *BatchRemoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRemoveResponse.ProtoReflect.Descriptor instead.
+func (*BatchRemoveResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BatchRemoveResponse) GetResult() []*BatchItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Outcome of a single batch item, in the order of the request items
+type BatchItemResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Index   uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Success bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// gRPC status code of the item, OK on success
+	Code  uint32 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Identifier of the saved pizza, unset for the validate_only batch
+	PizzaId uint64 `protobuf:"varint,5,opt,name=pizza_id,json=pizzaId,proto3" json:"pizza_id,omitempty"`
+	// Updated pizza, unset for the validate_only batch
+	Pizza         *PizzaProperties `protobuf:"bytes,6,opt,name=pizza,proto3" json:"pizza,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BatchItemResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchItemResult) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetPizzaId() uint64 {
+	if x != nil {
+		return x.PizzaId
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetPizza() *PizzaProperties {
+	if x != nil {
+		return x.Pizza
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Label string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// Roles of the key, checked against the access policy like the roles of the SSO users
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// The key never expires when it is unset
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateAPIKeyRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *APIKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Sent as the x-api-key metadata, it cannot be retrieved again
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateAPIKeyResponse) GetKey() *APIKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        uint32                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListAPIKeysRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAPIKeysRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []*APIKey              `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListAPIKeysResponse) GetKey() []*APIKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint64                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevokeAPIKeyRequest) GetKeyId() uint64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RevokeAPIKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// API key of a machine client, the secret is stored only as a hash
+type APIKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	KeyId uint64                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Public part of the secret, tells the keys apart in the logs
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *APIKey) GetKeyId() uint64 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pizza or category, all the entities when empty
+	Entity string `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Actor  string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Inclusive lower bound of the change time
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive upper bound of the change time
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Offset        uint32                 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAuditEventsRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The newest first
+	Event         []*AuditEvent `protobuf:"bytes,1,rep,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListAuditEventsResponse) GetEvent() []*AuditEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Single change of the catalog
+type AuditEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EventId    uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Subject of the caller, anonymous when the calls are not authenticated
+	Actor     string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	RequestId string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// save, update, remove or restore
+	Operation string `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	// pizza or category
+	Entity   string `protobuf:"bytes,6,opt,name=entity,proto3" json:"entity,omitempty"`
+	EntityId uint64 `protobuf:"varint,7,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// JSON of the entity before and after the change, empty when it was not live
+	Before        string `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"`
+	After         string `protobuf:"bytes,9,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AuditEvent) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type WatchMenuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only the categories with the ids and their pizzas are watched, the whole menu when empty
+	CategoryId []uint32 `protobuf:"varint,1,rep,packed,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Sequence number of the last update received, the changes after it are sent instead of the snapshot while they are kept
+	ResumeAfter   uint64 `protobuf:"varint,2,opt,name=resume_after,json=resumeAfter,proto3" json:"resume_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuRequest) Reset() {
+	*x = WatchMenuRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuRequest) ProtoMessage() {}
+
+func (x *WatchMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuRequest.ProtoReflect.Descriptor instead.
+func (*WatchMenuRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WatchMenuRequest) GetCategoryId() []uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return nil
+}
+
+func (x *WatchMenuRequest) GetResumeAfter() uint64 {
+	if x != nil {
+		return x.ResumeAfter
+	}
+	return 0
+}
+
+type WatchMenuResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence number of the change, the snapshot updates carry the one the snapshot was taken at
+	Seq  uint64         `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Kind MenuUpdateKind `protobuf:"varint,2,opt,name=kind,proto3,enum=github.nhassl3.pizzaland.PizzaLand.MenuUpdateKind" json:"kind,omitempty"`
+	// Not set for MENU_SNAPSHOT_END
+	//
+	// Types that are valid to be assigned to Entity:
+	//
+	//	*WatchMenuResponse_Pizza
+	//	*WatchMenuResponse_Category
+	Entity        isWatchMenuResponse_Entity `protobuf_oneof:"entity"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuResponse) Reset() {
+	*x = WatchMenuResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuResponse) ProtoMessage() {}
+
+func (x *WatchMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuResponse.ProtoReflect.Descriptor instead.
+func (*WatchMenuResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WatchMenuResponse) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *WatchMenuResponse) GetKind() MenuUpdateKind {
+	if x != nil {
+		return x.Kind
+	}
+	return MenuUpdateKind_MENU_UPDATE_KIND_UNSPECIFIED
+}
+
+func (x *WatchMenuResponse) GetEntity() isWatchMenuResponse_Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *WatchMenuResponse) GetPizza() *PizzaProperties {
+	if x != nil {
+		if x, ok := x.Entity.(*WatchMenuResponse_Pizza); ok {
+			return x.Pizza
+		}
+	}
+	return nil
+}
+
+func (x *WatchMenuResponse) GetCategory() *CategoryProperties {
+	if x != nil {
+		if x, ok := x.Entity.(*WatchMenuResponse_Category); ok {
+			return x.Category
+		}
+	}
+	return nil
+}
+
+type isWatchMenuResponse_Entity interface {
+	isWatchMenuResponse_Entity()
+}
+
+type WatchMenuResponse_Pizza struct {
+	Pizza *PizzaProperties `protobuf:"bytes,3,opt,name=pizza,proto3,oneof"`
+}
+
+type WatchMenuResponse_Category struct {
+	Category *CategoryProperties `protobuf:"bytes,4,opt,name=category,proto3,oneof"`
+}
+
+func (*WatchMenuResponse_Pizza) isWatchMenuResponse_Entity() {}
+
+func (*WatchMenuResponse_Category) isWatchMenuResponse_Entity() {}
+
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// http or https URL receiving the events as POST requests
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Types of the events sent to the webhook, e.g. pizzaland.pizza.updated
+	EventTypes []string `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Key of the HMAC-SHA256 signatures, generated when empty
+	Secret        string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type CreateWebhookResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Webhook *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// Verifies the X-PizzaLand-Signature header of the deliveries, it cannot be retrieved again
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *CreateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type GetWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookRequest) Reset() {
+	*x = GetWebhookRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookRequest) ProtoMessage() {}
+
+func (x *GetWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetWebhookRequest) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type GetWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookResponse) Reset() {
+	*x = GetWebhookResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookResponse) ProtoMessage() {}
+
+func (x *GetWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        uint32                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListWebhooksRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListWebhooksRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       []*Webhook             `protobuf:"bytes,1,rep,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListWebhooksResponse) GetWebhook() []*Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type UpdateWebhookRequest struct {
+	state     protoimpl.MessageState  `protogen:"open.v1"`
+	WebhookId uint64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url       *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	// Replaces the event types when not empty
+	EventTypes    []string                `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Secret        *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=secret,proto3,oneof" json:"secret,omitempty"`
+	Enabled       *wrapperspb.BoolValue   `protobuf:"bytes,5,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookRequest) Reset() {
+	*x = UpdateWebhookRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UpdateWebhookRequest) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *UpdateWebhookRequest) GetUrl() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetSecret() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetEnabled() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Enabled
+	}
+	return nil
+}
+
+type UpdateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookResponse) Reset() {
+	*x = UpdateWebhookResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookResponse) ProtoMessage() {}
+
+func (x *UpdateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *UpdateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeleteWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// pending, delivered or failed, all the deliveries when empty
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Offset        uint32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The newest first
+	Delivery      []*WebhookDelivery `protobuf:"bytes,1,rep,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDelivery() []*WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// Subscription of a partner to the change events, the secret is never returned after the creation
+type Webhook struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId  uint64                 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url        string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// False after the webhook was disabled by hand or after too many failed attempts in a row
+	Enabled             bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	ConsecutiveFailures uint32                 `protobuf:"varint,5,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DisabledAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Webhook) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Webhook) GetConsecutiveFailures() uint32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+// Single event sent to a webhook and the outcome of its last attempt
+type WebhookDelivery struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId uint64                 `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	WebhookId  uint64                 `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId    string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType  string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// pending, delivered or failed
+	Status   string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts uint32 `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// HTTP status of the last attempt, zero when there was no response
+	ResponseCode uint32                 `protobuf:"varint,7,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	LastError    string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Time of the next attempt of the pending delivery
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *WebhookDelivery) GetDeliveryId() uint64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetWebhookId() uint64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetResponseCode() uint32 {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// The first message carries the options, the next ones the content of the file
+type ImportMenuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Part:
+	//
+	//	*ImportMenuRequest_Options
+	//	*ImportMenuRequest_Chunk
+	Part          isImportMenuRequest_Part `protobuf_oneof:"part"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuRequest) Reset() {
+	*x = ImportMenuRequest{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuRequest) ProtoMessage() {}
+
+func (x *ImportMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuRequest.ProtoReflect.Descriptor instead.
+func (*ImportMenuRequest) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ImportMenuRequest) GetPart() isImportMenuRequest_Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *ImportMenuRequest) GetOptions() *ImportMenuOptions {
+	if x != nil {
+		if x, ok := x.Part.(*ImportMenuRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportMenuRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Part.(*ImportMenuRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportMenuRequest_Part interface {
+	isImportMenuRequest_Part()
+}
+
+type ImportMenuRequest_Options struct {
+	Options *ImportMenuOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportMenuRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportMenuRequest_Options) isImportMenuRequest_Part() {}
+
+func (*ImportMenuRequest_Chunk) isImportMenuRequest_Part() {}
+
+type ImportMenuOptions struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format MenuFileFormat         `protobuf:"varint,1,opt,name=format,proto3,enum=github.nhassl3.pizzaland.PizzaLand.MenuFileFormat" json:"format,omitempty"`
+	// Check every row and count the changes without writing them
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Replace the categories and pizzas with the names of the rows instead of reporting them as existing
+	Upsert        bool `protobuf:"varint,3,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuOptions) Reset() {
+	*x = ImportMenuOptions{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuOptions) ProtoMessage() {}
+
+func (x *ImportMenuOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuOptions.ProtoReflect.Descriptor instead.
+func (*ImportMenuOptions) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ImportMenuOptions) GetFormat() MenuFileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return MenuFileFormat_MENU_FILE_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportMenuOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportMenuOptions) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+type ImportMenuResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False on dry run or when any row failed, nothing was written then
+	Applied           bool   `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	CategoriesCreated uint32 `protobuf:"varint,2,opt,name=categories_created,json=categoriesCreated,proto3" json:"categories_created,omitempty"`
+	CategoriesUpdated uint32 `protobuf:"varint,3,opt,name=categories_updated,json=categoriesUpdated,proto3" json:"categories_updated,omitempty"`
+	PizzasCreated     uint32 `protobuf:"varint,4,opt,name=pizzas_created,json=pizzasCreated,proto3" json:"pizzas_created,omitempty"`
+	PizzasUpdated     uint32 `protobuf:"varint,5,opt,name=pizzas_updated,json=pizzasUpdated,proto3" json:"pizzas_updated,omitempty"`
+	// Upserted rows equal to the stored categories and pizzas
+	Unchanged     uint32            `protobuf:"varint,6,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Error         []*ImportRowError `protobuf:"bytes,7,rep,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuResponse) Reset() {
+	*x = ImportMenuResponse{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuResponse) ProtoMessage() {}
+
+func (x *ImportMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuResponse.ProtoReflect.Descriptor instead.
+func (*ImportMenuResponse) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ImportMenuResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ImportMenuResponse) GetCategoriesCreated() uint32 {
+	if x != nil {
+		return x.CategoriesCreated
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetCategoriesUpdated() uint32 {
+	if x != nil {
+		return x.CategoriesUpdated
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetPizzasCreated() uint32 {
+	if x != nil {
+		return x.PizzasCreated
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetPizzasUpdated() uint32 {
+	if x != nil {
+		return x.PizzasUpdated
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetUnchanged() uint32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportMenuResponse) GetError() []*ImportRowError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Problem of a single row of the menu file
+type ImportRowError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of the row starting from 1, the header of the CSV file is not counted
+	Row uint32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Line of the file the row starts at
+	Line uint32 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	// Column of the row, empty when the problem is not about one of them
+	Field         string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ImportRowError) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Composite structure for pizza properties
+type PizzaProperties struct {
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	PizzaId     *wrapperspb.UInt64Value `protobuf:"bytes,1,opt,name=pizza_id,json=pizzaId,proto3,oneof" json:"pizza_id,omitempty"`
+	CategoryId  uint32                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name        string                  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	TypeDough   TypeDough               `protobuf:"varint,5,opt,name=type_dough,json=typeDough,proto3,enum=github.nhassl3.pizzaland.PizzaLand.TypeDough" json:"type_dough,omitempty"`
+	Price       float32                 `protobuf:"fixed32,6,opt,name=price,proto3" json:"price,omitempty"`
+	Diameter    uint32                  `protobuf:"varint,7,opt,name=diameter,proto3" json:"diameter,omitempty"`
+	// Time of the removal, set only for the pizza in the trash
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Incremented on every change, pass it back to Update or Remove as a precondition
+	Version       uint64 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PizzaProperties) Reset() {
 	*x = PizzaProperties{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[18]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1117,7 +4165,7 @@ func (x *PizzaProperties) String() string {
 func (*PizzaProperties) ProtoMessage() {}
 
 func (x *PizzaProperties) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[18]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,7 +4178,7 @@ func (x *PizzaProperties) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PizzaProperties.ProtoReflect.Descriptor instead.
 func (*PizzaProperties) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{18}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *PizzaProperties) GetPizzaId() *wrapperspb.UInt64Value {
@@ -1182,18 +4230,36 @@ func (x *PizzaProperties) GetDiameter() uint32 {
 	return 0
 }
 
+func (x *PizzaProperties) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *PizzaProperties) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CategoryProperties struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	CategoryId    *wrapperspb.UInt32Value `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
-	Name          string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	CategoryId  *wrapperspb.UInt32Value `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Name        string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Time of the removal, set only for the category in the trash
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Incremented on every change, pass it back to UpdateCategory or RemoveCategory as a precondition
+	Version       uint64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CategoryProperties) Reset() {
 	*x = CategoryProperties{}
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[19]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1205,7 +4271,7 @@ func (x *CategoryProperties) String() string {
 func (*CategoryProperties) ProtoMessage() {}
 
 func (x *CategoryProperties) ProtoReflect() protoreflect.Message {
-	mi := &file_pizzaland_pizzaland_proto_msgTypes[19]
+	mi := &file_pizzaland_pizzaland_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,7 +4284,7 @@ func (x *CategoryProperties) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CategoryProperties.ProtoReflect.Descriptor instead.
 func (*CategoryProperties) Descriptor() ([]byte, []int) {
-	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{19}
+	return file_pizzaland_pizzaland_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *CategoryProperties) GetCategoryId() *wrapperspb.UInt32Value {
@@ -1242,13 +4308,28 @@ func (x *CategoryProperties) GetDescription() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *CategoryProperties) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *CategoryProperties) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 var File_pizzaland_pizzaland_proto protoreflect.FileDescriptor
 
 const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"\n" +
-	"\x19pizzaland/pizzaland.proto\x12\"github.nhassl3.pizzaland.PizzaLand\x1a6third_party/googleapis/google/api/field_behavior.proto\x1a\x17validate/validate.proto\x1a\x1egoogle/protobuf/wrappers.proto\"X\n" +
+	"\x19pizzaland/pizzaland.proto\x12\"github.nhassl3.pizzaland.PizzaLand\x1a3third_party/googleapis/google/api/annotations.proto\x1a6third_party/googleapis/google/api/field_behavior.proto\x1a\x17validate/validate.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\x8e\x01\n" +
 	"\vSaveRequest\x12I\n" +
-	"\x05pizza\x18\x01 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\")\n" +
+	"\x05pizza\x18\x01 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\x124\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tB\v\xe0A\x01\xfaB\x05r\x03\x18\xff\x01R\x0eidempotencyKey\")\n" +
 	"\fSaveResponse\x12\x19\n" +
 	"\bpizza_id\x18\x01 \x01(\x04R\apizzaId\"l\n" +
 	"\n" +
@@ -1272,7 +4353,7 @@ const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"\f_category_idB\x10\n" +
 	"\x0e_category_name\"Y\n" +
 	"\fListResponse\x12I\n" +
-	"\x05pizza\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\"\xbf\x04\n" +
+	"\x05pizza\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\"\x85\x05\n" +
 	"\rUpdateRequest\x12N\n" +
 	"\vcategory_id\x18\x01 \x01(\v2\x1c.google.protobuf.UInt32ValueB\n" +
 	"\xe0A\x01\xfaB\x04*\x02 \x00H\x00R\n" +
@@ -1283,7 +4364,10 @@ const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"type_dough\x18\x04 \x01(\x0e2-.github.nhassl3.pizzaland.PizzaLand.TypeDoughB\r\xe0A\x01\xfaB\a\x82\x01\x04\x10\x01 \x00H\x03R\ttypeDough\x88\x01\x01\x12E\n" +
 	"\x05price\x18\x05 \x01(\v2\x1b.google.protobuf.FloatValueB\r\xe0A\x01\xfaB\a\n" +
 	"\x05-\x00\x00\xdaBH\x04R\x05price\x88\x01\x01\x12M\n" +
-	"\bdiameter\x18\x06 \x01(\v2\x1c.google.protobuf.UInt32ValueB\x0e\xe0A\x01\xfaB\b*\x060\x1a0\x1e0(H\x05R\bdiameter\x88\x01\x01B\x0e\n" +
+	"\bdiameter\x18\x06 \x01(\v2\x1c.google.protobuf.UInt32ValueB\x0e\xe0A\x01\xfaB\b*\x060\x1a0\x1e0(H\x05R\bdiameter\x88\x01\x01\x12%\n" +
+	"\bpizza_id\x18\a \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\apizzaId\x12\x1d\n" +
+	"\aversion\x18\b \x01(\x04B\x03\xe0A\x01R\aversionB\x0e\n" +
 	"\f_category_idB\a\n" +
 	"\x05_nameB\x0e\n" +
 	"\f_descriptionB\r\n" +
@@ -1291,17 +4375,28 @@ const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"\x06_priceB\v\n" +
 	"\t_diameter\"*\n" +
 	"\x0eUpdateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"m\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf6\x01\n" +
+	"\x12UpdatePizzaRequest\x12%\n" +
+	"\bpizza_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\apizzaId\x12X\n" +
+	"\x05pizza\x18\x02 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesB\r\xe0A\x02\xfaB\a\x8a\x01\x04\b\x01\x10\x01R\x05pizza\x12@\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\n" +
+	"updateMask\x12\x1d\n" +
+	"\aversion\x18\x04 \x01(\x04B\x03\xe0A\x01R\aversion\"`\n" +
+	"\x13UpdatePizzaResponse\x12I\n" +
+	"\x05pizza\x18\x01 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\"\x8c\x01\n" +
 	"\rRemoveRequest\x12$\n" +
 	"\bpizza_id\x18\x01 \x01(\x04B\a\xfaB\x042\x02 \x00H\x00R\apizzaId\x12(\n" +
 	"\n" +
-	"pizza_name\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x03H\x00R\tpizzaNameB\f\n" +
+	"pizza_name\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x03H\x00R\tpizzaName\x12\x1d\n" +
+	"\aversion\x18\x03 \x01(\x04B\x03\xe0A\x01R\aversionB\f\n" +
 	"\n" +
 	"identifier\"*\n" +
 	"\x0eRemoveResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"i\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x9f\x01\n" +
 	"\x13SaveCategoryRequest\x12R\n" +
-	"\bcategory\x18\x01 \x01(\v26.github.nhassl3.pizzaland.PizzaLand.CategoryPropertiesR\bcategory\"7\n" +
+	"\bcategory\x18\x01 \x01(\v26.github.nhassl3.pizzaland.PizzaLand.CategoryPropertiesR\bcategory\x124\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tB\v\xe0A\x01\xfaB\x05r\x03\x18\xff\x01R\x0eidempotencyKey\"7\n" +
 	"\x14SaveCategoryResponse\x12\x1f\n" +
 	"\vcategory_id\x18\x01 \x01(\rR\n" +
 	"categoryId\"~\n" +
@@ -1312,22 +4407,251 @@ const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"\n" +
 	"identifier\"]\n" +
 	"\x13GetCategoryResponse\x12F\n" +
-	"\x05pizza\x18\x01 \x01(\v20.github.nhassl3.pizzaland.PizzaLand.ListResponseR\x05pizza\"\xc9\x01\n" +
+	"\x05pizza\x18\x01 \x01(\v20.github.nhassl3.pizzaland.PizzaLand.ListResponseR\x05pizza\"\x95\x02\n" +
 	"\x15UpdateCategoryRequest\x12C\n" +
 	"\x04name\x18\x01 \x01(\v2\x1c.google.protobuf.StringValueB\f\xe0A\x01\xfaB\x06r\x04\x10\x03\x18\x1aH\x00R\x04name\x88\x01\x01\x12R\n" +
-	"\vdescription\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\r\xe0A\x01\xfaB\ar\x05\x10\x10\x18\x80\x02H\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\vdescription\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\r\xe0A\x01\xfaB\ar\x05\x10\x10\x18\x80\x02H\x01R\vdescription\x88\x01\x01\x12+\n" +
+	"\vcategory_id\x18\x03 \x01(\rB\n" +
+	"\xe0A\x02\xfaB\x04*\x02 \x00R\n" +
+	"categoryId\x12\x1d\n" +
+	"\aversion\x18\x04 \x01(\x04B\x03\xe0A\x01R\aversionB\a\n" +
 	"\x05_nameB\x0e\n" +
 	"\f_description\"2\n" +
 	"\x16UpdateCategoryResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x81\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xa0\x01\n" +
 	"\x15RemoveCategoryRequest\x12*\n" +
 	"\vcategory_id\x18\x01 \x01(\x04B\a\xfaB\x042\x02 \x00H\x00R\n" +
 	"categoryId\x12.\n" +
-	"\rcategory_name\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x03H\x00R\fcategoryNameB\f\n" +
+	"\rcategory_name\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x03H\x00R\fcategoryName\x12\x1d\n" +
+	"\aversion\x18\x03 \x01(\x04B\x03\xe0A\x01R\aversionB\f\n" +
 	"\n" +
 	"identifier\"2\n" +
 	"\x16RemoveCategoryResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb4\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"7\n" +
+	"\x0eRestoreRequest\x12%\n" +
+	"\bpizza_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\apizzaId\"+\n" +
+	"\x0fRestoreResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\x12ListDeletedRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x02 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"`\n" +
+	"\x13ListDeletedResponse\x12I\n" +
+	"\x05pizza\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\"E\n" +
+	"\x16RestoreCategoryRequest\x12+\n" +
+	"\vcategory_id\x18\x01 \x01(\rB\n" +
+	"\xe0A\x02\xfaB\x04*\x02 \x00R\n" +
+	"categoryId\"3\n" +
+	"\x17RestoreCategoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"c\n" +
+	"\x1cListDeletedCategoriesRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x02 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"s\n" +
+	"\x1dListDeletedCategoriesResponse\x12R\n" +
+	"\bcategory\x18\x01 \x03(\v26.github.nhassl3.pizzaland.PizzaLand.CategoryPropertiesR\bcategory\"\xc4\x01\n" +
+	"\x10BatchSaveRequest\x12`\n" +
+	"\x05pizza\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesB\x15\xe0A\x02\xfaB\x0f\x92\x01\f\b\x01\x10\xf4\x03\"\x05\x8a\x01\x02\b\x01R\x05pizza\x12$\n" +
+	"\vbest_effort\x18\x02 \x01(\bB\x03\xe0A\x01R\n" +
+	"bestEffort\x12(\n" +
+	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\"`\n" +
+	"\x11BatchSaveResponse\x12K\n" +
+	"\x06result\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.BatchItemResultR\x06result\"\xc9\x01\n" +
+	"\x12BatchUpdateRequest\x12c\n" +
+	"\x05pizza\x18\x01 \x03(\v26.github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequestB\x15\xe0A\x02\xfaB\x0f\x92\x01\f\b\x01\x10\xf4\x03\"\x05\x8a\x01\x02\b\x01R\x05pizza\x12$\n" +
+	"\vbest_effort\x18\x02 \x01(\bB\x03\xe0A\x01R\n" +
+	"bestEffort\x12(\n" +
+	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\"b\n" +
+	"\x13BatchUpdateResponse\x12K\n" +
+	"\x06result\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.BatchItemResultR\x06result\"\xc4\x01\n" +
+	"\x12BatchRemoveRequest\x12^\n" +
+	"\x05pizza\x18\x01 \x03(\v21.github.nhassl3.pizzaland.PizzaLand.RemoveRequestB\x15\xe0A\x02\xfaB\x0f\x92\x01\f\b\x01\x10\xf4\x03\"\x05\x8a\x01\x02\b\x01R\x05pizza\x12$\n" +
+	"\vbest_effort\x18\x02 \x01(\bB\x03\xe0A\x01R\n" +
+	"bestEffort\x12(\n" +
+	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\"b\n" +
+	"\x13BatchRemoveResponse\x12K\n" +
+	"\x06result\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.BatchItemResultR\x06result\"\xd1\x01\n" +
+	"\x0fBatchItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\rR\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x19\n" +
+	"\bpizza_id\x18\x05 \x01(\x04R\apizzaId\x12I\n" +
+	"\x05pizza\x18\x06 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesR\x05pizza\"\xb2\x01\n" +
+	"\x13CreateAPIKeyRequest\x12\"\n" +
+	"\x05label\x18\x01 \x01(\tB\f\xe0A\x02\xfaB\x06r\x04\x10\x01\x18@R\x05label\x12/\n" +
+	"\x06scopes\x18\x02 \x03(\tB\x17\xe0A\x02\xfaB\x11\x92\x01\x0e\b\x01\x10\x10\x18\x01\"\x06r\x04\x10\x01\x18 R\x06scopes\x12F\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\v\xe0A\x01\xfaB\x05\xb2\x01\x02@\x01R\texpiresAt\"l\n" +
+	"\x14CreateAPIKeyResponse\x12<\n" +
+	"\x03key\x18\x01 \x01(\v2*.github.nhassl3.pizzaland.PizzaLand.APIKeyR\x03key\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"Y\n" +
+	"\x12ListAPIKeysRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x02 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"S\n" +
+	"\x13ListAPIKeysResponse\x12<\n" +
+	"\x03key\x18\x01 \x03(\v2*.github.nhassl3.pizzaland.PizzaLand.APIKeyR\x03key\"8\n" +
+	"\x13RevokeAPIKeyRequest\x12!\n" +
+	"\x06key_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\x05keyId\"0\n" +
+	"\x14RevokeAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd4\x02\n" +
+	"\x06APIKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\x04R\x05keyId\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"\x9c\x02\n" +
+	"\x16ListAuditEventsRequest\x124\n" +
+	"\x06entity\x18\x01 \x01(\tB\x1c\xe0A\x01\xfaB\x16r\x14R\x05pizzaR\bcategory\xd0\x01\x01R\x06entity\x12!\n" +
+	"\x05actor\x18\x02 \x01(\tB\v\xe0A\x01\xfaB\x05r\x03\x18\xff\x01R\x05actor\x123\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\x04from\x12/\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\x02to\x12\x1b\n" +
+	"\x06offset\x18\x05 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x06 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"_\n" +
+	"\x17ListAuditEventsResponse\x12D\n" +
+	"\x05event\x18\x01 \x03(\v2..github.nhassl3.pizzaland.PizzaLand.AuditEventR\x05event\"\x9a\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12\x16\n" +
+	"\x06entity\x18\x06 \x01(\tR\x06entity\x12\x1b\n" +
+	"\tentity_id\x18\a \x01(\x04R\bentityId\x12\x16\n" +
+	"\x06before\x18\b \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\t \x01(\tR\x05after\"p\n" +
+	"\x10WatchMenuRequest\x124\n" +
+	"\vcategory_id\x18\x01 \x03(\rB\x13\xe0A\x01\xfaB\r\x92\x01\n" +
+	"\x10d\x18\x01\"\x04*\x02 \x00R\n" +
+	"categoryId\x12&\n" +
+	"\fresume_after\x18\x02 \x01(\x04B\x03\xe0A\x01R\vresumeAfter\"\x9a\x02\n" +
+	"\x11WatchMenuResponse\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12F\n" +
+	"\x04kind\x18\x02 \x01(\x0e22.github.nhassl3.pizzaland.PizzaLand.MenuUpdateKindR\x04kind\x12K\n" +
+	"\x05pizza\x18\x03 \x01(\v23.github.nhassl3.pizzaland.PizzaLand.PizzaPropertiesH\x00R\x05pizza\x12T\n" +
+	"\bcategory\x18\x04 \x01(\v26.github.nhassl3.pizzaland.PizzaLand.CategoryPropertiesH\x00R\bcategoryB\b\n" +
+	"\x06entity\"\xfe\x02\n" +
+	"\x14CreateWebhookRequest\x12,\n" +
+	"\x03url\x18\x01 \x01(\tB\x1a\xe0A\x02\xfaB\x14r\x12\x18\x80\x102\n" +
+	"^https?://\x88\x01\x01R\x03url\x12\x8d\x02\n" +
+	"\vevent_types\x18\x02 \x03(\tB\xeb\x01\xe0A\x02\xfaB\xe4\x01\x92\x01\xe0\x01\b\x01\x18\x01\"\xd9\x01r\xd6\x01R\x17pizzaland.pizza.createdR\x17pizzaland.pizza.updatedR\x17pizzaland.pizza.deletedR\x18pizzaland.pizza.restoredR\x1apizzaland.category.createdR\x1apizzaland.category.updatedR\x1apizzaland.category.deletedR\x1bpizzaland.category.restoredR\n" +
+	"eventTypes\x12(\n" +
+	"\x06secret\x18\x03 \x01(\tB\x10\xe0A\x01\xfaB\n" +
+	"r\b\x10\x10\x18\x80\x01\xd0\x01\x01R\x06secret\"v\n" +
+	"\x15CreateWebhookResponse\x12E\n" +
+	"\awebhook\x18\x01 \x01(\v2+.github.nhassl3.pizzaland.PizzaLand.WebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\">\n" +
+	"\x11GetWebhookRequest\x12)\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\twebhookId\"[\n" +
+	"\x12GetWebhookResponse\x12E\n" +
+	"\awebhook\x18\x01 \x01(\v2+.github.nhassl3.pizzaland.PizzaLand.WebhookR\awebhook\"Z\n" +
+	"\x13ListWebhooksRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x02 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"]\n" +
+	"\x14ListWebhooksResponse\x12E\n" +
+	"\awebhook\x18\x01 \x03(\v2+.github.nhassl3.pizzaland.PizzaLand.WebhookR\awebhook\"\xc9\x04\n" +
+	"\x14UpdateWebhookRequest\x12)\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\twebhookId\x12O\n" +
+	"\x03url\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\x1a\xe0A\x01\xfaB\x14r\x12\x18\x80\x102\n" +
+	"^https?://\x88\x01\x01H\x00R\x03url\x88\x01\x01\x12\x8b\x02\n" +
+	"\vevent_types\x18\x03 \x03(\tB\xe9\x01\xe0A\x01\xfaB\xe2\x01\x92\x01\xde\x01\x18\x01\"\xd9\x01r\xd6\x01R\x17pizzaland.pizza.createdR\x17pizzaland.pizza.updatedR\x17pizzaland.pizza.deletedR\x18pizzaland.pizza.restoredR\x1apizzaland.category.createdR\x1apizzaland.category.updatedR\x1apizzaland.category.deletedR\x1bpizzaland.category.restoredR\n" +
+	"eventTypes\x12H\n" +
+	"\x06secret\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueB\r\xe0A\x01\xfaB\ar\x05\x10\x10\x18\x80\x01H\x01R\x06secret\x88\x01\x01\x12>\n" +
+	"\aenabled\x18\x05 \x01(\v2\x1a.google.protobuf.BoolValueB\x03\xe0A\x01H\x02R\aenabled\x88\x01\x01B\x06\n" +
+	"\x04_urlB\t\n" +
+	"\a_secretB\n" +
+	"\n" +
+	"\b_enabled\"^\n" +
+	"\x15UpdateWebhookResponse\x12E\n" +
+	"\awebhook\x18\x01 \x01(\v2+.github.nhassl3.pizzaland.PizzaLand.WebhookR\awebhook\"A\n" +
+	"\x14DeleteWebhookRequest\x12)\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\twebhookId\"1\n" +
+	"\x15DeleteWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xcf\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12)\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04B\n" +
+	"\xe0A\x02\xfaB\x042\x02 \x00R\twebhookId\x12?\n" +
+	"\x06status\x18\x02 \x01(\tB'\xe0A\x01\xfaB!r\x1fR\apendingR\tdeliveredR\x06failed\xd0\x01\x01R\x06status\x12\x1b\n" +
+	"\x06offset\x18\x03 \x01(\rB\x03\xe0A\x01R\x06offset\x12&\n" +
+	"\x05limit\x18\x04 \x01(\rB\x10\xe0A\x02\xfaB\n" +
+	"*\b0\f0\x180$00R\x05limit\"p\n" +
+	"\x1dListWebhookDeliveriesResponse\x12O\n" +
+	"\bdelivery\x18\x01 \x03(\v23.github.nhassl3.pizzaland.PizzaLand.WebhookDeliveryR\bdelivery\"\xdb\x02\n" +
+	"\aWebhook\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x04R\twebhookId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x121\n" +
+	"\x14consecutive_failures\x18\x05 \x01(\rR\x13consecutiveFailures\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vdisabled_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\"\xc1\x03\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x04R\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x04R\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\rR\battempts\x12#\n" +
+	"\rresponse_code\x18\a \x01(\rR\fresponseCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x94\x01\n" +
+	"\x11ImportMenuRequest\x12Q\n" +
+	"\aoptions\x18\x01 \x01(\v25.github.nhassl3.pizzaland.PizzaLand.ImportMenuOptionsH\x00R\aoptions\x12\x1f\n" +
+	"\x05chunk\x18\x02 \x01(\fB\a\xfaB\x04z\x02\x10\x01H\x00R\x05chunkB\v\n" +
+	"\x04part\x12\x03\xf8B\x01\"\xa9\x01\n" +
+	"\x11ImportMenuOptions\x12Y\n" +
+	"\x06format\x18\x01 \x01(\x0e22.github.nhassl3.pizzaland.PizzaLand.MenuFileFormatB\r\xe0A\x02\xfaB\a\x82\x01\x04\x10\x01 \x00R\x06format\x12\x1c\n" +
+	"\adry_run\x18\x02 \x01(\bB\x03\xe0A\x01R\x06dryRun\x12\x1b\n" +
+	"\x06upsert\x18\x03 \x01(\bB\x03\xe0A\x01R\x06upsert\"\xc2\x02\n" +
+	"\x12ImportMenuResponse\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\bR\aapplied\x12-\n" +
+	"\x12categories_created\x18\x02 \x01(\rR\x11categoriesCreated\x12-\n" +
+	"\x12categories_updated\x18\x03 \x01(\rR\x11categoriesUpdated\x12%\n" +
+	"\x0epizzas_created\x18\x04 \x01(\rR\rpizzasCreated\x12%\n" +
+	"\x0epizzas_updated\x18\x05 \x01(\rR\rpizzasUpdated\x12\x1c\n" +
+	"\tunchanged\x18\x06 \x01(\rR\tunchanged\x12H\n" +
+	"\x05error\x18\a \x03(\v22.github.nhassl3.pizzaland.PizzaLand.ImportRowErrorR\x05error\"f\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\rR\x03row\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\rR\x04line\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x93\x04\n" +
 	"\x0fPizzaProperties\x12H\n" +
 	"\bpizza_id\x18\x01 \x01(\v2\x1c.google.protobuf.UInt64ValueB\n" +
 	"\xe0A\x01\xfaB\x042\x02 \x00H\x00R\apizzaId\x88\x01\x01\x12+\n" +
@@ -1340,30 +4664,72 @@ const file_pizzaland_pizzaland_proto_rawDesc = "" +
 	"type_dough\x18\x05 \x01(\x0e2-.github.nhassl3.pizzaland.PizzaLand.TypeDoughB\r\xe0A\x02\xfaB\a\x82\x01\x04\x10\x01 \x00R\ttypeDough\x12#\n" +
 	"\x05price\x18\x06 \x01(\x02B\r\xe0A\x02\xfaB\a\n" +
 	"\x05-\x00\x00\xdaBR\x05price\x12*\n" +
-	"\bdiameter\x18\a \x01(\rB\x0e\xe0A\x02\xfaB\b*\x060\x1a0\x1e0(R\bdiameterB\v\n" +
-	"\t_pizza_id\"\xe5\x01\n" +
+	"\bdiameter\x18\a \x01(\rB\x0e\xe0A\x02\xfaB\b*\x060\x1a0\x1e0(R\bdiameter\x12>\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tdeletedAt\x12\x1d\n" +
+	"\aversion\x18\t \x01(\x04B\x03\xe0A\x03R\aversionB\v\n" +
+	"\t_pizza_id\"\xc4\x02\n" +
 	"\x12CategoryProperties\x12N\n" +
 	"\vcategory_id\x18\x01 \x01(\v2\x1c.google.protobuf.UInt32ValueB\n" +
 	"\xe0A\x01\xfaB\x04*\x02 \x00H\x00R\n" +
 	"categoryId\x88\x01\x01\x12 \n" +
 	"\x04name\x18\x02 \x01(\tB\f\xe0A\x02\xfaB\x06r\x04\x10\x03\x18\x1aR\x04name\x12M\n" +
-	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueB\r\xe0A\x01\xfaB\ar\x05\x10\x10\x18\x80\x02R\vdescriptionB\x0e\n" +
-	"\f_category_id*?\n" +
+	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueB\r\xe0A\x01\xfaB\ar\x05\x10\x10\x18\x80\x02R\vdescription\x12>\n" +
+	"\n" +
+	"deleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tdeletedAt\x12\x1d\n" +
+	"\aversion\x18\x05 \x01(\x04B\x03\xe0A\x03R\aversionB\x0e\n" +
+	"\f_category_id*\x92\x01\n" +
+	"\x0eMenuUpdateKind\x12 \n" +
+	"\x1cMENU_UPDATE_KIND_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rMENU_SNAPSHOT\x10\x01\x12\x15\n" +
+	"\x11MENU_SNAPSHOT_END\x10\x02\x12\x10\n" +
+	"\fMENU_CREATED\x10\x03\x12\x10\n" +
+	"\fMENU_UPDATED\x10\x04\x12\x10\n" +
+	"\fMENU_DELETED\x10\x05*m\n" +
+	"\x0eMenuFileFormat\x12 \n" +
+	"\x1cMENU_FILE_FORMAT_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rMENU_FILE_CSV\x10\x01\x12\x12\n" +
+	"\x0eMENU_FILE_JSON\x10\x02\x12\x12\n" +
+	"\x0eMENU_FILE_YAML\x10\x03*?\n" +
 	"\tTypeDough\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\x15\n" +
 	"\x11TRADITIONAL_DOUGH\x10\x01\x12\x0e\n" +
 	"\n" +
-	"THIN_DOUGH\x10\x022\xc3\b\n" +
-	"\tPizzaLand\x12i\n" +
-	"\x04Save\x12/.github.nhassl3.pizzaland.PizzaLand.SaveRequest\x1a0.github.nhassl3.pizzaland.PizzaLand.SaveResponse\x12f\n" +
-	"\x03Get\x12..github.nhassl3.pizzaland.PizzaLand.GetRequest\x1a/.github.nhassl3.pizzaland.PizzaLand.GetResponse\x12i\n" +
-	"\x04List\x12/.github.nhassl3.pizzaland.PizzaLand.ListRequest\x1a0.github.nhassl3.pizzaland.PizzaLand.ListResponse\x12o\n" +
-	"\x06Update\x121.github.nhassl3.pizzaland.PizzaLand.UpdateRequest\x1a2.github.nhassl3.pizzaland.PizzaLand.UpdateResponse\x12o\n" +
-	"\x06Remove\x121.github.nhassl3.pizzaland.PizzaLand.RemoveRequest\x1a2.github.nhassl3.pizzaland.PizzaLand.RemoveResponse\x12\x81\x01\n" +
-	"\fSaveCategory\x127.github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest\x1a8.github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse\x12~\n" +
-	"\vGetCategory\x126.github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse\x12\x87\x01\n" +
-	"\x0eUpdateCategory\x129.github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest\x1a:.github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse\x12\x87\x01\n" +
-	"\x0eRemoveCategory\x129.github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest\x1a:.github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponseB(Z&github.nhassl3.pizzaland.v1;pizzalndv1b\x06proto3"
+	"THIN_DOUGH\x10\x022\xd7%\n" +
+	"\tPizzaLand\x12\x80\x01\n" +
+	"\x04Save\x12/.github.nhassl3.pizzaland.PizzaLand.SaveRequest\x1a0.github.nhassl3.pizzaland.PizzaLand.SaveResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/pizzas\x12\xa5\x01\n" +
+	"\x03Get\x12..github.nhassl3.pizzaland.PizzaLand.GetRequest\x1a/.github.nhassl3.pizzaland.PizzaLand.GetResponse\"=\x82\xd3\xe4\x93\x027Z\x1e\x12\x1c/v1/pizzas/name/{pizza_name}\x12\x15/v1/pizzas/{pizza_id}\x12}\n" +
+	"\x04List\x12/.github.nhassl3.pizzaland.PizzaLand.ListRequest\x1a0.github.nhassl3.pizzaland.PizzaLand.ListResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/pizzas\x12\x98\x01\n" +
+	"\x06Update\x121.github.nhassl3.pizzaland.PizzaLand.UpdateRequest\x1a2.github.nhassl3.pizzaland.PizzaLand.UpdateResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/pizzas/{pizza_id}:update\x12\xae\x01\n" +
+	"\x06Remove\x121.github.nhassl3.pizzaland.PizzaLand.RemoveRequest\x1a2.github.nhassl3.pizzaland.PizzaLand.RemoveResponse\"=\x82\xd3\xe4\x93\x027Z\x1e*\x1c/v1/pizzas/name/{pizza_name}*\x15/v1/pizzas/{pizza_id}\x12\x9c\x01\n" +
+	"\fSaveCategory\x127.github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest\x1a8.github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/categories\x12\xd9\x01\n" +
+	"\vGetCategory\x126.github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse\"Y\x82\xd3\xe4\x93\x02SZ,\x12*/v1/categories/name/{category_name}/pizzas\x12#/v1/categories/{category_id}/pizzas\x12\xb0\x01\n" +
+	"\x0eUpdateCategory\x129.github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest\x1a:.github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse\"'\x82\xd3\xe4\x93\x02!:\x01*2\x1c/v1/categories/{category_id}\x12\xd4\x01\n" +
+	"\x0eRemoveCategory\x129.github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest\x1a:.github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponse\"K\x82\xd3\xe4\x93\x02EZ%*#/v1/categories/name/{category_name}*\x1c/v1/categories/{category_id}\x12\x9c\x01\n" +
+	"\aRestore\x122.github.nhassl3.pizzaland.PizzaLand.RestoreRequest\x1a3.github.nhassl3.pizzaland.PizzaLand.RestoreResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/pizzas/{pizza_id}:restore\x12\x98\x01\n" +
+	"\vListDeleted\x126.github.nhassl3.pizzaland.PizzaLand.ListDeletedRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.ListDeletedResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/trash/pizzas\x12\xbb\x01\n" +
+	"\x0fRestoreCategory\x12:.github.nhassl3.pizzaland.PizzaLand.RestoreCategoryRequest\x1a;.github.nhassl3.pizzaland.PizzaLand.RestoreCategoryResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/categories/{category_id}:restore\x12\xba\x01\n" +
+	"\x15ListDeletedCategories\x12@.github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesRequest\x1aA.github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/trash/categories\x12\xa4\x01\n" +
+	"\vUpdatePizza\x126.github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.UpdatePizzaResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x05pizza2\x15/v1/pizzas/{pizza_id}\x12\x99\x01\n" +
+	"\tBatchSave\x124.github.nhassl3.pizzaland.PizzaLand.BatchSaveRequest\x1a5.github.nhassl3.pizzaland.PizzaLand.BatchSaveResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/pizzas:batchSave\x12\xa1\x01\n" +
+	"\vBatchUpdate\x126.github.nhassl3.pizzaland.PizzaLand.BatchUpdateRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.BatchUpdateResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/pizzas:batchUpdate\x12\xa1\x01\n" +
+	"\vBatchRemove\x126.github.nhassl3.pizzaland.PizzaLand.BatchRemoveRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.BatchRemoveResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/pizzas:batchRemove\x12\x9a\x01\n" +
+	"\fCreateAPIKey\x127.github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyRequest\x1a8.github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/api-keys\x12\x94\x01\n" +
+	"\vListAPIKeys\x126.github.nhassl3.pizzaland.PizzaLand.ListAPIKeysRequest\x1a7.github.nhassl3.pizzaland.PizzaLand.ListAPIKeysResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/api-keys\x12\xa0\x01\n" +
+	"\fRevokeAPIKey\x127.github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyRequest\x1a8.github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/api-keys/{key_id}\x12\xa4\x01\n" +
+	"\x0fListAuditEvents\x12:.github.nhassl3.pizzaland.PizzaLand.ListAuditEventsRequest\x1a;.github.nhassl3.pizzaland.PizzaLand.ListAuditEventsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit-events\x12\x92\x01\n" +
+	"\tWatchMenu\x124.github.nhassl3.pizzaland.PizzaLand.WatchMenuRequest\x1a5.github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/menu:watch0\x01\x12\x9d\x01\n" +
+	"\rCreateWebhook\x128.github.nhassl3.pizzaland.PizzaLand.CreateWebhookRequest\x1a9.github.nhassl3.pizzaland.PizzaLand.CreateWebhookResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12\x9e\x01\n" +
+	"\n" +
+	"GetWebhook\x125.github.nhassl3.pizzaland.PizzaLand.GetWebhookRequest\x1a6.github.nhassl3.pizzaland.PizzaLand.GetWebhookResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/webhooks/{webhook_id}\x12\x97\x01\n" +
+	"\fListWebhooks\x127.github.nhassl3.pizzaland.PizzaLand.ListWebhooksRequest\x1a8.github.nhassl3.pizzaland.PizzaLand.ListWebhooksResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x12\xaa\x01\n" +
+	"\rUpdateWebhook\x128.github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest\x1a9.github.nhassl3.pizzaland.PizzaLand.UpdateWebhookResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*2\x19/v1/webhooks/{webhook_id}\x12\xa7\x01\n" +
+	"\rDeleteWebhook\x128.github.nhassl3.pizzaland.PizzaLand.DeleteWebhookRequest\x1a9.github.nhassl3.pizzaland.PizzaLand.DeleteWebhookResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/webhooks/{webhook_id}\x12\xca\x01\n" +
+	"\x15ListWebhookDeliveries\x12@.github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesRequest\x1aA.github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/webhooks/{webhook_id}/deliveries\x12}\n" +
+	"\n" +
+	"ImportMenu\x125.github.nhassl3.pizzaland.PizzaLand.ImportMenuRequest\x1a6.github.nhassl3.pizzaland.PizzaLand.ImportMenuResponse(\x01B(Z&github.nhassl3.pizzaland.v1;pizzalndv1b\x06proto3"
 
 var (
 	file_pizzaland_pizzaland_proto_rawDescOnce sync.Once
@@ -1377,79 +4743,216 @@ func file_pizzaland_pizzaland_proto_rawDescGZIP() []byte {
 	return file_pizzaland_pizzaland_proto_rawDescData
 }
 
-var file_pizzaland_pizzaland_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pizzaland_pizzaland_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_pizzaland_pizzaland_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_pizzaland_pizzaland_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_pizzaland_pizzaland_proto_goTypes = []any{
-	(TypeDough)(0),                 // 0: github.nhassl3.pizzaland.PizzaLand.TypeDough
-	(*SaveRequest)(nil),            // 1: github.nhassl3.pizzaland.PizzaLand.SaveRequest
-	(*SaveResponse)(nil),           // 2: github.nhassl3.pizzaland.PizzaLand.SaveResponse
-	(*GetRequest)(nil),             // 3: github.nhassl3.pizzaland.PizzaLand.GetRequest
-	(*GetResponse)(nil),            // 4: github.nhassl3.pizzaland.PizzaLand.GetResponse
-	(*ListRequest)(nil),            // 5: github.nhassl3.pizzaland.PizzaLand.ListRequest
-	(*ListResponse)(nil),           // 6: github.nhassl3.pizzaland.PizzaLand.ListResponse
-	(*UpdateRequest)(nil),          // 7: github.nhassl3.pizzaland.PizzaLand.UpdateRequest
-	(*UpdateResponse)(nil),         // 8: github.nhassl3.pizzaland.PizzaLand.UpdateResponse
-	(*RemoveRequest)(nil),          // 9: github.nhassl3.pizzaland.PizzaLand.RemoveRequest
-	(*RemoveResponse)(nil),         // 10: github.nhassl3.pizzaland.PizzaLand.RemoveResponse
-	(*SaveCategoryRequest)(nil),    // 11: github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest
-	(*SaveCategoryResponse)(nil),   // 12: github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse
-	(*GetCategoryRequest)(nil),     // 13: github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest
-	(*GetCategoryResponse)(nil),    // 14: github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse
-	(*UpdateCategoryRequest)(nil),  // 15: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest
-	(*UpdateCategoryResponse)(nil), // 16: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse
-	(*RemoveCategoryRequest)(nil),  // 17: github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest
-	(*RemoveCategoryResponse)(nil), // 18: github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponse
-	(*PizzaProperties)(nil),        // 19: github.nhassl3.pizzaland.PizzaLand.PizzaProperties
-	(*CategoryProperties)(nil),     // 20: github.nhassl3.pizzaland.PizzaLand.CategoryProperties
-	(*wrapperspb.UInt32Value)(nil), // 21: google.protobuf.UInt32Value
-	(*wrapperspb.StringValue)(nil), // 22: google.protobuf.StringValue
-	(*wrapperspb.FloatValue)(nil),  // 23: google.protobuf.FloatValue
-	(*wrapperspb.UInt64Value)(nil), // 24: google.protobuf.UInt64Value
+	(MenuUpdateKind)(0),                   // 0: github.nhassl3.pizzaland.PizzaLand.MenuUpdateKind
+	(MenuFileFormat)(0),                   // 1: github.nhassl3.pizzaland.PizzaLand.MenuFileFormat
+	(TypeDough)(0),                        // 2: github.nhassl3.pizzaland.PizzaLand.TypeDough
+	(*SaveRequest)(nil),                   // 3: github.nhassl3.pizzaland.PizzaLand.SaveRequest
+	(*SaveResponse)(nil),                  // 4: github.nhassl3.pizzaland.PizzaLand.SaveResponse
+	(*GetRequest)(nil),                    // 5: github.nhassl3.pizzaland.PizzaLand.GetRequest
+	(*GetResponse)(nil),                   // 6: github.nhassl3.pizzaland.PizzaLand.GetResponse
+	(*ListRequest)(nil),                   // 7: github.nhassl3.pizzaland.PizzaLand.ListRequest
+	(*ListResponse)(nil),                  // 8: github.nhassl3.pizzaland.PizzaLand.ListResponse
+	(*UpdateRequest)(nil),                 // 9: github.nhassl3.pizzaland.PizzaLand.UpdateRequest
+	(*UpdateResponse)(nil),                // 10: github.nhassl3.pizzaland.PizzaLand.UpdateResponse
+	(*UpdatePizzaRequest)(nil),            // 11: github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest
+	(*UpdatePizzaResponse)(nil),           // 12: github.nhassl3.pizzaland.PizzaLand.UpdatePizzaResponse
+	(*RemoveRequest)(nil),                 // 13: github.nhassl3.pizzaland.PizzaLand.RemoveRequest
+	(*RemoveResponse)(nil),                // 14: github.nhassl3.pizzaland.PizzaLand.RemoveResponse
+	(*SaveCategoryRequest)(nil),           // 15: github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest
+	(*SaveCategoryResponse)(nil),          // 16: github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse
+	(*GetCategoryRequest)(nil),            // 17: github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest
+	(*GetCategoryResponse)(nil),           // 18: github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse
+	(*UpdateCategoryRequest)(nil),         // 19: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),        // 20: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse
+	(*RemoveCategoryRequest)(nil),         // 21: github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest
+	(*RemoveCategoryResponse)(nil),        // 22: github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponse
+	(*RestoreRequest)(nil),                // 23: github.nhassl3.pizzaland.PizzaLand.RestoreRequest
+	(*RestoreResponse)(nil),               // 24: github.nhassl3.pizzaland.PizzaLand.RestoreResponse
+	(*ListDeletedRequest)(nil),            // 25: github.nhassl3.pizzaland.PizzaLand.ListDeletedRequest
+	(*ListDeletedResponse)(nil),           // 26: github.nhassl3.pizzaland.PizzaLand.ListDeletedResponse
+	(*RestoreCategoryRequest)(nil),        // 27: github.nhassl3.pizzaland.PizzaLand.RestoreCategoryRequest
+	(*RestoreCategoryResponse)(nil),       // 28: github.nhassl3.pizzaland.PizzaLand.RestoreCategoryResponse
+	(*ListDeletedCategoriesRequest)(nil),  // 29: github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesRequest
+	(*ListDeletedCategoriesResponse)(nil), // 30: github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesResponse
+	(*BatchSaveRequest)(nil),              // 31: github.nhassl3.pizzaland.PizzaLand.BatchSaveRequest
+	(*BatchSaveResponse)(nil),             // 32: github.nhassl3.pizzaland.PizzaLand.BatchSaveResponse
+	(*BatchUpdateRequest)(nil),            // 33: github.nhassl3.pizzaland.PizzaLand.BatchUpdateRequest
+	(*BatchUpdateResponse)(nil),           // 34: github.nhassl3.pizzaland.PizzaLand.BatchUpdateResponse
+	(*BatchRemoveRequest)(nil),            // 35: github.nhassl3.pizzaland.PizzaLand.BatchRemoveRequest
+	(*BatchRemoveResponse)(nil),           // 36: github.nhassl3.pizzaland.PizzaLand.BatchRemoveResponse
+	(*BatchItemResult)(nil),               // 37: github.nhassl3.pizzaland.PizzaLand.BatchItemResult
+	(*CreateAPIKeyRequest)(nil),           // 38: github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),          // 39: github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),            // 40: github.nhassl3.pizzaland.PizzaLand.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),           // 41: github.nhassl3.pizzaland.PizzaLand.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),           // 42: github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),          // 43: github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyResponse
+	(*APIKey)(nil),                        // 44: github.nhassl3.pizzaland.PizzaLand.APIKey
+	(*ListAuditEventsRequest)(nil),        // 45: github.nhassl3.pizzaland.PizzaLand.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),       // 46: github.nhassl3.pizzaland.PizzaLand.ListAuditEventsResponse
+	(*AuditEvent)(nil),                    // 47: github.nhassl3.pizzaland.PizzaLand.AuditEvent
+	(*WatchMenuRequest)(nil),              // 48: github.nhassl3.pizzaland.PizzaLand.WatchMenuRequest
+	(*WatchMenuResponse)(nil),             // 49: github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse
+	(*CreateWebhookRequest)(nil),          // 50: github.nhassl3.pizzaland.PizzaLand.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),         // 51: github.nhassl3.pizzaland.PizzaLand.CreateWebhookResponse
+	(*GetWebhookRequest)(nil),             // 52: github.nhassl3.pizzaland.PizzaLand.GetWebhookRequest
+	(*GetWebhookResponse)(nil),            // 53: github.nhassl3.pizzaland.PizzaLand.GetWebhookResponse
+	(*ListWebhooksRequest)(nil),           // 54: github.nhassl3.pizzaland.PizzaLand.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 55: github.nhassl3.pizzaland.PizzaLand.ListWebhooksResponse
+	(*UpdateWebhookRequest)(nil),          // 56: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest
+	(*UpdateWebhookResponse)(nil),         // 57: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookResponse
+	(*DeleteWebhookRequest)(nil),          // 58: github.nhassl3.pizzaland.PizzaLand.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),         // 59: github.nhassl3.pizzaland.PizzaLand.DeleteWebhookResponse
+	(*ListWebhookDeliveriesRequest)(nil),  // 60: github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 61: github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesResponse
+	(*Webhook)(nil),                       // 62: github.nhassl3.pizzaland.PizzaLand.Webhook
+	(*WebhookDelivery)(nil),               // 63: github.nhassl3.pizzaland.PizzaLand.WebhookDelivery
+	(*ImportMenuRequest)(nil),             // 64: github.nhassl3.pizzaland.PizzaLand.ImportMenuRequest
+	(*ImportMenuOptions)(nil),             // 65: github.nhassl3.pizzaland.PizzaLand.ImportMenuOptions
+	(*ImportMenuResponse)(nil),            // 66: github.nhassl3.pizzaland.PizzaLand.ImportMenuResponse
+	(*ImportRowError)(nil),                // 67: github.nhassl3.pizzaland.PizzaLand.ImportRowError
+	(*PizzaProperties)(nil),               // 68: github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	(*CategoryProperties)(nil),            // 69: github.nhassl3.pizzaland.PizzaLand.CategoryProperties
+	(*wrapperspb.UInt32Value)(nil),        // 70: google.protobuf.UInt32Value
+	(*wrapperspb.StringValue)(nil),        // 71: google.protobuf.StringValue
+	(*wrapperspb.FloatValue)(nil),         // 72: google.protobuf.FloatValue
+	(*fieldmaskpb.FieldMask)(nil),         // 73: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),         // 74: google.protobuf.Timestamp
+	(*wrapperspb.BoolValue)(nil),          // 75: google.protobuf.BoolValue
+	(*wrapperspb.UInt64Value)(nil),        // 76: google.protobuf.UInt64Value
 }
 var file_pizzaland_pizzaland_proto_depIdxs = []int32{
-	19, // 0: github.nhassl3.pizzaland.PizzaLand.SaveRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
-	19, // 1: github.nhassl3.pizzaland.PizzaLand.GetResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
-	21, // 2: github.nhassl3.pizzaland.PizzaLand.ListRequest.category_id:type_name -> google.protobuf.UInt32Value
-	22, // 3: github.nhassl3.pizzaland.PizzaLand.ListRequest.category_name:type_name -> google.protobuf.StringValue
-	19, // 4: github.nhassl3.pizzaland.PizzaLand.ListResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
-	21, // 5: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.category_id:type_name -> google.protobuf.UInt32Value
-	22, // 6: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.name:type_name -> google.protobuf.StringValue
-	22, // 7: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.description:type_name -> google.protobuf.StringValue
-	0,  // 8: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.type_dough:type_name -> github.nhassl3.pizzaland.PizzaLand.TypeDough
-	23, // 9: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.price:type_name -> google.protobuf.FloatValue
-	21, // 10: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.diameter:type_name -> google.protobuf.UInt32Value
-	20, // 11: github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest.category:type_name -> github.nhassl3.pizzaland.PizzaLand.CategoryProperties
-	6,  // 12: github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.ListResponse
-	22, // 13: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest.name:type_name -> google.protobuf.StringValue
-	22, // 14: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest.description:type_name -> google.protobuf.StringValue
-	24, // 15: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.pizza_id:type_name -> google.protobuf.UInt64Value
-	22, // 16: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.description:type_name -> google.protobuf.StringValue
-	0,  // 17: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.type_dough:type_name -> github.nhassl3.pizzaland.PizzaLand.TypeDough
-	21, // 18: github.nhassl3.pizzaland.PizzaLand.CategoryProperties.category_id:type_name -> google.protobuf.UInt32Value
-	22, // 19: github.nhassl3.pizzaland.PizzaLand.CategoryProperties.description:type_name -> google.protobuf.StringValue
-	1,  // 20: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Save:input_type -> github.nhassl3.pizzaland.PizzaLand.SaveRequest
-	3,  // 21: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Get:input_type -> github.nhassl3.pizzaland.PizzaLand.GetRequest
-	5,  // 22: github.nhassl3.pizzaland.PizzaLand.PizzaLand.List:input_type -> github.nhassl3.pizzaland.PizzaLand.ListRequest
-	7,  // 23: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Update:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdateRequest
-	9,  // 24: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Remove:input_type -> github.nhassl3.pizzaland.PizzaLand.RemoveRequest
-	11, // 25: github.nhassl3.pizzaland.PizzaLand.PizzaLand.SaveCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest
-	13, // 26: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest
-	15, // 27: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest
-	17, // 28: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RemoveCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest
-	2,  // 29: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Save:output_type -> github.nhassl3.pizzaland.PizzaLand.SaveResponse
-	4,  // 30: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Get:output_type -> github.nhassl3.pizzaland.PizzaLand.GetResponse
-	6,  // 31: github.nhassl3.pizzaland.PizzaLand.PizzaLand.List:output_type -> github.nhassl3.pizzaland.PizzaLand.ListResponse
-	8,  // 32: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Update:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdateResponse
-	10, // 33: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Remove:output_type -> github.nhassl3.pizzaland.PizzaLand.RemoveResponse
-	12, // 34: github.nhassl3.pizzaland.PizzaLand.PizzaLand.SaveCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse
-	14, // 35: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse
-	16, // 36: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse
-	18, // 37: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RemoveCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponse
-	29, // [29:38] is the sub-list for method output_type
-	20, // [20:29] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	68, // 0: github.nhassl3.pizzaland.PizzaLand.SaveRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	68, // 1: github.nhassl3.pizzaland.PizzaLand.GetResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	70, // 2: github.nhassl3.pizzaland.PizzaLand.ListRequest.category_id:type_name -> google.protobuf.UInt32Value
+	71, // 3: github.nhassl3.pizzaland.PizzaLand.ListRequest.category_name:type_name -> google.protobuf.StringValue
+	68, // 4: github.nhassl3.pizzaland.PizzaLand.ListResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	70, // 5: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.category_id:type_name -> google.protobuf.UInt32Value
+	71, // 6: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.name:type_name -> google.protobuf.StringValue
+	71, // 7: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.description:type_name -> google.protobuf.StringValue
+	2,  // 8: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.type_dough:type_name -> github.nhassl3.pizzaland.PizzaLand.TypeDough
+	72, // 9: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.price:type_name -> google.protobuf.FloatValue
+	70, // 10: github.nhassl3.pizzaland.PizzaLand.UpdateRequest.diameter:type_name -> google.protobuf.UInt32Value
+	68, // 11: github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	73, // 12: github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest.update_mask:type_name -> google.protobuf.FieldMask
+	68, // 13: github.nhassl3.pizzaland.PizzaLand.UpdatePizzaResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	69, // 14: github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest.category:type_name -> github.nhassl3.pizzaland.PizzaLand.CategoryProperties
+	8,  // 15: github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.ListResponse
+	71, // 16: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest.name:type_name -> google.protobuf.StringValue
+	71, // 17: github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest.description:type_name -> google.protobuf.StringValue
+	68, // 18: github.nhassl3.pizzaland.PizzaLand.ListDeletedResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	69, // 19: github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesResponse.category:type_name -> github.nhassl3.pizzaland.PizzaLand.CategoryProperties
+	68, // 20: github.nhassl3.pizzaland.PizzaLand.BatchSaveRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	37, // 21: github.nhassl3.pizzaland.PizzaLand.BatchSaveResponse.result:type_name -> github.nhassl3.pizzaland.PizzaLand.BatchItemResult
+	11, // 22: github.nhassl3.pizzaland.PizzaLand.BatchUpdateRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest
+	37, // 23: github.nhassl3.pizzaland.PizzaLand.BatchUpdateResponse.result:type_name -> github.nhassl3.pizzaland.PizzaLand.BatchItemResult
+	13, // 24: github.nhassl3.pizzaland.PizzaLand.BatchRemoveRequest.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.RemoveRequest
+	37, // 25: github.nhassl3.pizzaland.PizzaLand.BatchRemoveResponse.result:type_name -> github.nhassl3.pizzaland.PizzaLand.BatchItemResult
+	68, // 26: github.nhassl3.pizzaland.PizzaLand.BatchItemResult.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	74, // 27: github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	44, // 28: github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyResponse.key:type_name -> github.nhassl3.pizzaland.PizzaLand.APIKey
+	44, // 29: github.nhassl3.pizzaland.PizzaLand.ListAPIKeysResponse.key:type_name -> github.nhassl3.pizzaland.PizzaLand.APIKey
+	74, // 30: github.nhassl3.pizzaland.PizzaLand.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	74, // 31: github.nhassl3.pizzaland.PizzaLand.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	74, // 32: github.nhassl3.pizzaland.PizzaLand.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	74, // 33: github.nhassl3.pizzaland.PizzaLand.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	74, // 34: github.nhassl3.pizzaland.PizzaLand.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	74, // 35: github.nhassl3.pizzaland.PizzaLand.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	47, // 36: github.nhassl3.pizzaland.PizzaLand.ListAuditEventsResponse.event:type_name -> github.nhassl3.pizzaland.PizzaLand.AuditEvent
+	74, // 37: github.nhassl3.pizzaland.PizzaLand.AuditEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	0,  // 38: github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse.kind:type_name -> github.nhassl3.pizzaland.PizzaLand.MenuUpdateKind
+	68, // 39: github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse.pizza:type_name -> github.nhassl3.pizzaland.PizzaLand.PizzaProperties
+	69, // 40: github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse.category:type_name -> github.nhassl3.pizzaland.PizzaLand.CategoryProperties
+	62, // 41: github.nhassl3.pizzaland.PizzaLand.CreateWebhookResponse.webhook:type_name -> github.nhassl3.pizzaland.PizzaLand.Webhook
+	62, // 42: github.nhassl3.pizzaland.PizzaLand.GetWebhookResponse.webhook:type_name -> github.nhassl3.pizzaland.PizzaLand.Webhook
+	62, // 43: github.nhassl3.pizzaland.PizzaLand.ListWebhooksResponse.webhook:type_name -> github.nhassl3.pizzaland.PizzaLand.Webhook
+	71, // 44: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest.url:type_name -> google.protobuf.StringValue
+	71, // 45: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest.secret:type_name -> google.protobuf.StringValue
+	75, // 46: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest.enabled:type_name -> google.protobuf.BoolValue
+	62, // 47: github.nhassl3.pizzaland.PizzaLand.UpdateWebhookResponse.webhook:type_name -> github.nhassl3.pizzaland.PizzaLand.Webhook
+	63, // 48: github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesResponse.delivery:type_name -> github.nhassl3.pizzaland.PizzaLand.WebhookDelivery
+	74, // 49: github.nhassl3.pizzaland.PizzaLand.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	74, // 50: github.nhassl3.pizzaland.PizzaLand.Webhook.updated_at:type_name -> google.protobuf.Timestamp
+	74, // 51: github.nhassl3.pizzaland.PizzaLand.Webhook.disabled_at:type_name -> google.protobuf.Timestamp
+	74, // 52: github.nhassl3.pizzaland.PizzaLand.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	74, // 53: github.nhassl3.pizzaland.PizzaLand.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	74, // 54: github.nhassl3.pizzaland.PizzaLand.WebhookDelivery.completed_at:type_name -> google.protobuf.Timestamp
+	65, // 55: github.nhassl3.pizzaland.PizzaLand.ImportMenuRequest.options:type_name -> github.nhassl3.pizzaland.PizzaLand.ImportMenuOptions
+	1,  // 56: github.nhassl3.pizzaland.PizzaLand.ImportMenuOptions.format:type_name -> github.nhassl3.pizzaland.PizzaLand.MenuFileFormat
+	67, // 57: github.nhassl3.pizzaland.PizzaLand.ImportMenuResponse.error:type_name -> github.nhassl3.pizzaland.PizzaLand.ImportRowError
+	76, // 58: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.pizza_id:type_name -> google.protobuf.UInt64Value
+	71, // 59: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.description:type_name -> google.protobuf.StringValue
+	2,  // 60: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.type_dough:type_name -> github.nhassl3.pizzaland.PizzaLand.TypeDough
+	74, // 61: github.nhassl3.pizzaland.PizzaLand.PizzaProperties.deleted_at:type_name -> google.protobuf.Timestamp
+	70, // 62: github.nhassl3.pizzaland.PizzaLand.CategoryProperties.category_id:type_name -> google.protobuf.UInt32Value
+	71, // 63: github.nhassl3.pizzaland.PizzaLand.CategoryProperties.description:type_name -> google.protobuf.StringValue
+	74, // 64: github.nhassl3.pizzaland.PizzaLand.CategoryProperties.deleted_at:type_name -> google.protobuf.Timestamp
+	3,  // 65: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Save:input_type -> github.nhassl3.pizzaland.PizzaLand.SaveRequest
+	5,  // 66: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Get:input_type -> github.nhassl3.pizzaland.PizzaLand.GetRequest
+	7,  // 67: github.nhassl3.pizzaland.PizzaLand.PizzaLand.List:input_type -> github.nhassl3.pizzaland.PizzaLand.ListRequest
+	9,  // 68: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Update:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdateRequest
+	13, // 69: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Remove:input_type -> github.nhassl3.pizzaland.PizzaLand.RemoveRequest
+	15, // 70: github.nhassl3.pizzaland.PizzaLand.PizzaLand.SaveCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.SaveCategoryRequest
+	17, // 71: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.GetCategoryRequest
+	19, // 72: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdateCategoryRequest
+	21, // 73: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RemoveCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.RemoveCategoryRequest
+	23, // 74: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Restore:input_type -> github.nhassl3.pizzaland.PizzaLand.RestoreRequest
+	25, // 75: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListDeleted:input_type -> github.nhassl3.pizzaland.PizzaLand.ListDeletedRequest
+	27, // 76: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RestoreCategory:input_type -> github.nhassl3.pizzaland.PizzaLand.RestoreCategoryRequest
+	29, // 77: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListDeletedCategories:input_type -> github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesRequest
+	11, // 78: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdatePizza:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdatePizzaRequest
+	31, // 79: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchSave:input_type -> github.nhassl3.pizzaland.PizzaLand.BatchSaveRequest
+	33, // 80: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchUpdate:input_type -> github.nhassl3.pizzaland.PizzaLand.BatchUpdateRequest
+	35, // 81: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchRemove:input_type -> github.nhassl3.pizzaland.PizzaLand.BatchRemoveRequest
+	38, // 82: github.nhassl3.pizzaland.PizzaLand.PizzaLand.CreateAPIKey:input_type -> github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyRequest
+	40, // 83: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListAPIKeys:input_type -> github.nhassl3.pizzaland.PizzaLand.ListAPIKeysRequest
+	42, // 84: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RevokeAPIKey:input_type -> github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyRequest
+	45, // 85: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListAuditEvents:input_type -> github.nhassl3.pizzaland.PizzaLand.ListAuditEventsRequest
+	48, // 86: github.nhassl3.pizzaland.PizzaLand.PizzaLand.WatchMenu:input_type -> github.nhassl3.pizzaland.PizzaLand.WatchMenuRequest
+	50, // 87: github.nhassl3.pizzaland.PizzaLand.PizzaLand.CreateWebhook:input_type -> github.nhassl3.pizzaland.PizzaLand.CreateWebhookRequest
+	52, // 88: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetWebhook:input_type -> github.nhassl3.pizzaland.PizzaLand.GetWebhookRequest
+	54, // 89: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListWebhooks:input_type -> github.nhassl3.pizzaland.PizzaLand.ListWebhooksRequest
+	56, // 90: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateWebhook:input_type -> github.nhassl3.pizzaland.PizzaLand.UpdateWebhookRequest
+	58, // 91: github.nhassl3.pizzaland.PizzaLand.PizzaLand.DeleteWebhook:input_type -> github.nhassl3.pizzaland.PizzaLand.DeleteWebhookRequest
+	60, // 92: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListWebhookDeliveries:input_type -> github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesRequest
+	64, // 93: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ImportMenu:input_type -> github.nhassl3.pizzaland.PizzaLand.ImportMenuRequest
+	4,  // 94: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Save:output_type -> github.nhassl3.pizzaland.PizzaLand.SaveResponse
+	6,  // 95: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Get:output_type -> github.nhassl3.pizzaland.PizzaLand.GetResponse
+	8,  // 96: github.nhassl3.pizzaland.PizzaLand.PizzaLand.List:output_type -> github.nhassl3.pizzaland.PizzaLand.ListResponse
+	10, // 97: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Update:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdateResponse
+	14, // 98: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Remove:output_type -> github.nhassl3.pizzaland.PizzaLand.RemoveResponse
+	16, // 99: github.nhassl3.pizzaland.PizzaLand.PizzaLand.SaveCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.SaveCategoryResponse
+	18, // 100: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.GetCategoryResponse
+	20, // 101: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdateCategoryResponse
+	22, // 102: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RemoveCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.RemoveCategoryResponse
+	24, // 103: github.nhassl3.pizzaland.PizzaLand.PizzaLand.Restore:output_type -> github.nhassl3.pizzaland.PizzaLand.RestoreResponse
+	26, // 104: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListDeleted:output_type -> github.nhassl3.pizzaland.PizzaLand.ListDeletedResponse
+	28, // 105: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RestoreCategory:output_type -> github.nhassl3.pizzaland.PizzaLand.RestoreCategoryResponse
+	30, // 106: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListDeletedCategories:output_type -> github.nhassl3.pizzaland.PizzaLand.ListDeletedCategoriesResponse
+	12, // 107: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdatePizza:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdatePizzaResponse
+	32, // 108: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchSave:output_type -> github.nhassl3.pizzaland.PizzaLand.BatchSaveResponse
+	34, // 109: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchUpdate:output_type -> github.nhassl3.pizzaland.PizzaLand.BatchUpdateResponse
+	36, // 110: github.nhassl3.pizzaland.PizzaLand.PizzaLand.BatchRemove:output_type -> github.nhassl3.pizzaland.PizzaLand.BatchRemoveResponse
+	39, // 111: github.nhassl3.pizzaland.PizzaLand.PizzaLand.CreateAPIKey:output_type -> github.nhassl3.pizzaland.PizzaLand.CreateAPIKeyResponse
+	41, // 112: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListAPIKeys:output_type -> github.nhassl3.pizzaland.PizzaLand.ListAPIKeysResponse
+	43, // 113: github.nhassl3.pizzaland.PizzaLand.PizzaLand.RevokeAPIKey:output_type -> github.nhassl3.pizzaland.PizzaLand.RevokeAPIKeyResponse
+	46, // 114: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListAuditEvents:output_type -> github.nhassl3.pizzaland.PizzaLand.ListAuditEventsResponse
+	49, // 115: github.nhassl3.pizzaland.PizzaLand.PizzaLand.WatchMenu:output_type -> github.nhassl3.pizzaland.PizzaLand.WatchMenuResponse
+	51, // 116: github.nhassl3.pizzaland.PizzaLand.PizzaLand.CreateWebhook:output_type -> github.nhassl3.pizzaland.PizzaLand.CreateWebhookResponse
+	53, // 117: github.nhassl3.pizzaland.PizzaLand.PizzaLand.GetWebhook:output_type -> github.nhassl3.pizzaland.PizzaLand.GetWebhookResponse
+	55, // 118: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListWebhooks:output_type -> github.nhassl3.pizzaland.PizzaLand.ListWebhooksResponse
+	57, // 119: github.nhassl3.pizzaland.PizzaLand.PizzaLand.UpdateWebhook:output_type -> github.nhassl3.pizzaland.PizzaLand.UpdateWebhookResponse
+	59, // 120: github.nhassl3.pizzaland.PizzaLand.PizzaLand.DeleteWebhook:output_type -> github.nhassl3.pizzaland.PizzaLand.DeleteWebhookResponse
+	61, // 121: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ListWebhookDeliveries:output_type -> github.nhassl3.pizzaland.PizzaLand.ListWebhookDeliveriesResponse
+	66, // 122: github.nhassl3.pizzaland.PizzaLand.PizzaLand.ImportMenu:output_type -> github.nhassl3.pizzaland.PizzaLand.ImportMenuResponse
+	94, // [94:123] is the sub-list for method output_type
+	65, // [65:94] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
 }
 
 func init() { file_pizzaland_pizzaland_proto_init() }
@@ -1463,28 +4966,37 @@ func file_pizzaland_pizzaland_proto_init() {
 	}
 	file_pizzaland_pizzaland_proto_msgTypes[4].OneofWrappers = []any{}
 	file_pizzaland_pizzaland_proto_msgTypes[6].OneofWrappers = []any{}
-	file_pizzaland_pizzaland_proto_msgTypes[8].OneofWrappers = []any{
+	file_pizzaland_pizzaland_proto_msgTypes[10].OneofWrappers = []any{
 		(*RemoveRequest_PizzaId)(nil),
 		(*RemoveRequest_PizzaName)(nil),
 	}
-	file_pizzaland_pizzaland_proto_msgTypes[12].OneofWrappers = []any{
+	file_pizzaland_pizzaland_proto_msgTypes[14].OneofWrappers = []any{
 		(*GetCategoryRequest_CategoryId)(nil),
 		(*GetCategoryRequest_CategoryName)(nil),
 	}
-	file_pizzaland_pizzaland_proto_msgTypes[14].OneofWrappers = []any{}
-	file_pizzaland_pizzaland_proto_msgTypes[16].OneofWrappers = []any{
+	file_pizzaland_pizzaland_proto_msgTypes[16].OneofWrappers = []any{}
+	file_pizzaland_pizzaland_proto_msgTypes[18].OneofWrappers = []any{
 		(*RemoveCategoryRequest_CategoryId)(nil),
 		(*RemoveCategoryRequest_CategoryName)(nil),
 	}
-	file_pizzaland_pizzaland_proto_msgTypes[18].OneofWrappers = []any{}
-	file_pizzaland_pizzaland_proto_msgTypes[19].OneofWrappers = []any{}
+	file_pizzaland_pizzaland_proto_msgTypes[46].OneofWrappers = []any{
+		(*WatchMenuResponse_Pizza)(nil),
+		(*WatchMenuResponse_Category)(nil),
+	}
+	file_pizzaland_pizzaland_proto_msgTypes[53].OneofWrappers = []any{}
+	file_pizzaland_pizzaland_proto_msgTypes[61].OneofWrappers = []any{
+		(*ImportMenuRequest_Options)(nil),
+		(*ImportMenuRequest_Chunk)(nil),
+	}
+	file_pizzaland_pizzaland_proto_msgTypes[65].OneofWrappers = []any{}
+	file_pizzaland_pizzaland_proto_msgTypes[66].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pizzaland_pizzaland_proto_rawDesc), len(file_pizzaland_pizzaland_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   20,
+			NumEnums:      3,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,30 +19,99 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PizzaLand_Save_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Save"
-	PizzaLand_Get_FullMethodName            = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Get"
-	PizzaLand_List_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/List"
-	PizzaLand_Update_FullMethodName         = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Update"
-	PizzaLand_Remove_FullMethodName         = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Remove"
-	PizzaLand_SaveCategory_FullMethodName   = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/SaveCategory"
-	PizzaLand_GetCategory_FullMethodName    = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/GetCategory"
-	PizzaLand_UpdateCategory_FullMethodName = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/UpdateCategory"
-	PizzaLand_RemoveCategory_FullMethodName = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/RemoveCategory"
+	PizzaLand_Save_FullMethodName                  = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Save"
+	PizzaLand_Get_FullMethodName                   = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Get"
+	PizzaLand_List_FullMethodName                  = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/List"
+	PizzaLand_Update_FullMethodName                = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Update"
+	PizzaLand_Remove_FullMethodName                = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Remove"
+	PizzaLand_SaveCategory_FullMethodName          = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/SaveCategory"
+	PizzaLand_GetCategory_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/GetCategory"
+	PizzaLand_UpdateCategory_FullMethodName        = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/UpdateCategory"
+	PizzaLand_RemoveCategory_FullMethodName        = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/RemoveCategory"
+	PizzaLand_Restore_FullMethodName               = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/Restore"
+	PizzaLand_ListDeleted_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListDeleted"
+	PizzaLand_RestoreCategory_FullMethodName       = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/RestoreCategory"
+	PizzaLand_ListDeletedCategories_FullMethodName = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListDeletedCategories"
+	PizzaLand_UpdatePizza_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/UpdatePizza"
+	PizzaLand_BatchSave_FullMethodName             = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/BatchSave"
+	PizzaLand_BatchUpdate_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/BatchUpdate"
+	PizzaLand_BatchRemove_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/BatchRemove"
+	PizzaLand_CreateAPIKey_FullMethodName          = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/CreateAPIKey"
+	PizzaLand_ListAPIKeys_FullMethodName           = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListAPIKeys"
+	PizzaLand_RevokeAPIKey_FullMethodName          = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/RevokeAPIKey"
+	PizzaLand_ListAuditEvents_FullMethodName       = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListAuditEvents"
+	PizzaLand_WatchMenu_FullMethodName             = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/WatchMenu"
+	PizzaLand_CreateWebhook_FullMethodName         = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/CreateWebhook"
+	PizzaLand_GetWebhook_FullMethodName            = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/GetWebhook"
+	PizzaLand_ListWebhooks_FullMethodName          = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListWebhooks"
+	PizzaLand_UpdateWebhook_FullMethodName         = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/UpdateWebhook"
+	PizzaLand_DeleteWebhook_FullMethodName         = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/DeleteWebhook"
+	PizzaLand_ListWebhookDeliveries_FullMethodName = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ListWebhookDeliveries"
+	PizzaLand_ImportMenu_FullMethodName            = "/github.nhassl3.pizzaland.PizzaLand.PizzaLand/ImportMenu"
 )
 
 // PizzaLandClient is the client API for PizzaLand service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PizzaLandClient interface {
+	// Save pizza procedure
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error)
+	// Get pizza procedure
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// Get list of the pizza procedure
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Update pizza properties or price procedure
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Remove pizza from system procedure
 	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
+	// Save category for pizza on the system procedure
 	SaveCategory(ctx context.Context, in *SaveCategoryRequest, opts ...grpc.CallOption) (*SaveCategoryResponse, error)
+	// Get list of the category procedure
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
+	// Update category properties procedure
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
+	// Remove category from the system procedure
 	RemoveCategory(ctx context.Context, in *RemoveCategoryRequest, opts ...grpc.CallOption) (*RemoveCategoryResponse, error)
+	// Restore removed pizza from the trash procedure
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// Get list of the removed pizza procedure
+	ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error)
+	// Restore removed category from the trash procedure
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error)
+	// Get list of the removed category procedure
+	ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListDeletedCategoriesResponse, error)
+	// Update masked pizza properties procedure
+	UpdatePizza(ctx context.Context, in *UpdatePizzaRequest, opts ...grpc.CallOption) (*UpdatePizzaResponse, error)
+	// Save several pizza in one transaction procedure
+	BatchSave(ctx context.Context, in *BatchSaveRequest, opts ...grpc.CallOption) (*BatchSaveResponse, error)
+	// Update several pizza in one transaction procedure
+	BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error)
+	// Remove several pizza in one transaction procedure
+	BatchRemove(ctx context.Context, in *BatchRemoveRequest, opts ...grpc.CallOption) (*BatchRemoveResponse, error)
+	// Create the API key of a machine client procedure, the secret is returned only once
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// Get list of the API keys procedure
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	// Revoke the API key procedure
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error)
+	// Get list of the catalog changes procedure
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// Watch the menu procedure, the snapshot followed by the live changes
+	WatchMenu(ctx context.Context, in *WatchMenuRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMenuResponse], error)
+	// Subscribe the webhook to the change events procedure, the secret is returned only once
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// Get the webhook procedure
+	GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*GetWebhookResponse, error)
+	// Get list of the webhooks procedure
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// Update the webhook procedure, enabling it again resets its failures
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*UpdateWebhookResponse, error)
+	// Delete the webhook with its deliveries procedure
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// Get list of the deliveries to the webhook procedure
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// Import the categories and pizzas of the menu file procedure, the options followed by the file in chunks
+	ImportMenu(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportMenuRequest, ImportMenuResponse], error)
 }
 
 type pizzaLandClient struct {
@@ -143,19 +212,280 @@ func (c *pizzaLandClient) RemoveCategory(ctx context.Context, in *RemoveCategory
 	return out, nil
 }
 
+func (c *pizzaLandClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListDeleted(ctx context.Context, in *ListDeletedRequest, opts ...grpc.CallOption) (*ListDeletedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListDeleted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreCategoryResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_RestoreCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListDeletedCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedCategoriesResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListDeletedCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) UpdatePizza(ctx context.Context, in *UpdatePizzaRequest, opts ...grpc.CallOption) (*UpdatePizzaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePizzaResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_UpdatePizza_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) BatchSave(ctx context.Context, in *BatchSaveRequest, opts ...grpc.CallOption) (*BatchSaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchSaveResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_BatchSave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_BatchUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) BatchRemove(ctx context.Context, in *BatchRemoveRequest, opts ...grpc.CallOption) (*BatchRemoveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRemoveResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_BatchRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAPIKeyResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) WatchMenu(ctx context.Context, in *WatchMenuRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMenuResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PizzaLand_ServiceDesc.Streams[0], PizzaLand_WatchMenu_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMenuRequest, WatchMenuResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PizzaLand_WatchMenuClient = grpc.ServerStreamingClient[WatchMenuResponse]
+
+func (c *pizzaLandClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*GetWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWebhookResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_GetWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*UpdateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWebhookResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, PizzaLand_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pizzaLandClient) ImportMenu(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportMenuRequest, ImportMenuResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PizzaLand_ServiceDesc.Streams[1], PizzaLand_ImportMenu_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportMenuRequest, ImportMenuResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PizzaLand_ImportMenuClient = grpc.ClientStreamingClient[ImportMenuRequest, ImportMenuResponse]
+
 // PizzaLandServer is the server API for PizzaLand service.
 // All implementations must embed UnimplementedPizzaLandServer
 // for forward compatibility.
 type PizzaLandServer interface {
+	// Save pizza procedure
 	Save(context.Context, *SaveRequest) (*SaveResponse, error)
+	// Get pizza procedure
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// Get list of the pizza procedure
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Update pizza properties or price procedure
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Remove pizza from system procedure
 	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
+	// Save category for pizza on the system procedure
 	SaveCategory(context.Context, *SaveCategoryRequest) (*SaveCategoryResponse, error)
+	// Get list of the category procedure
 	GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error)
+	// Update category properties procedure
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
+	// Remove category from the system procedure
 	RemoveCategory(context.Context, *RemoveCategoryRequest) (*RemoveCategoryResponse, error)
+	// Restore removed pizza from the trash procedure
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// Get list of the removed pizza procedure
+	ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error)
+	// Restore removed category from the trash procedure
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error)
+	// Get list of the removed category procedure
+	ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListDeletedCategoriesResponse, error)
+	// Update masked pizza properties procedure
+	UpdatePizza(context.Context, *UpdatePizzaRequest) (*UpdatePizzaResponse, error)
+	// Save several pizza in one transaction procedure
+	BatchSave(context.Context, *BatchSaveRequest) (*BatchSaveResponse, error)
+	// Update several pizza in one transaction procedure
+	BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error)
+	// Remove several pizza in one transaction procedure
+	BatchRemove(context.Context, *BatchRemoveRequest) (*BatchRemoveResponse, error)
+	// Create the API key of a machine client procedure, the secret is returned only once
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// Get list of the API keys procedure
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// Revoke the API key procedure
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error)
+	// Get list of the catalog changes procedure
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// Watch the menu procedure, the snapshot followed by the live changes
+	WatchMenu(*WatchMenuRequest, grpc.ServerStreamingServer[WatchMenuResponse]) error
+	// Subscribe the webhook to the change events procedure, the secret is returned only once
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// Get the webhook procedure
+	GetWebhook(context.Context, *GetWebhookRequest) (*GetWebhookResponse, error)
+	// Get list of the webhooks procedure
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// Update the webhook procedure, enabling it again resets its failures
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*UpdateWebhookResponse, error)
+	// Delete the webhook with its deliveries procedure
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// Get list of the deliveries to the webhook procedure
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// Import the categories and pizzas of the menu file procedure, the options followed by the file in chunks
+	ImportMenu(grpc.ClientStreamingServer[ImportMenuRequest, ImportMenuResponse]) error
 	mustEmbedUnimplementedPizzaLandServer()
 }
 
@@ -193,6 +523,66 @@ func (UnimplementedPizzaLandServer) UpdateCategory(context.Context, *UpdateCateg
 func (UnimplementedPizzaLandServer) RemoveCategory(context.Context, *RemoveCategoryRequest) (*RemoveCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveCategory not implemented")
 }
+func (UnimplementedPizzaLandServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedPizzaLandServer) ListDeleted(context.Context, *ListDeletedRequest) (*ListDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (UnimplementedPizzaLandServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCategory not implemented")
+}
+func (UnimplementedPizzaLandServer) ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListDeletedCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedCategories not implemented")
+}
+func (UnimplementedPizzaLandServer) UpdatePizza(context.Context, *UpdatePizzaRequest) (*UpdatePizzaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePizza not implemented")
+}
+func (UnimplementedPizzaLandServer) BatchSave(context.Context, *BatchSaveRequest) (*BatchSaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSave not implemented")
+}
+func (UnimplementedPizzaLandServer) BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
+}
+func (UnimplementedPizzaLandServer) BatchRemove(context.Context, *BatchRemoveRequest) (*BatchRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRemove not implemented")
+}
+func (UnimplementedPizzaLandServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedPizzaLandServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedPizzaLandServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedPizzaLandServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedPizzaLandServer) WatchMenu(*WatchMenuRequest, grpc.ServerStreamingServer[WatchMenuResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMenu not implemented")
+}
+func (UnimplementedPizzaLandServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedPizzaLandServer) GetWebhook(context.Context, *GetWebhookRequest) (*GetWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (UnimplementedPizzaLandServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedPizzaLandServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*UpdateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedPizzaLandServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedPizzaLandServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedPizzaLandServer) ImportMenu(grpc.ClientStreamingServer[ImportMenuRequest, ImportMenuResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportMenu not implemented")
+}
 func (UnimplementedPizzaLandServer) mustEmbedUnimplementedPizzaLandServer() {}
 func (UnimplementedPizzaLandServer) testEmbeddedByValue()                   {}
 
@@ -376,6 +766,348 @@ func _PizzaLand_RemoveCategory_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PizzaLand_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListDeleted(ctx, req.(*ListDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_RestoreCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListDeletedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListDeletedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListDeletedCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListDeletedCategories(ctx, req.(*ListDeletedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_UpdatePizza_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePizzaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).UpdatePizza(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_UpdatePizza_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).UpdatePizza(ctx, req.(*UpdatePizzaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_BatchSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).BatchSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_BatchSave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).BatchSave(ctx, req.(*BatchSaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).BatchUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_BatchUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).BatchUpdate(ctx, req.(*BatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_BatchRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).BatchRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_BatchRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).BatchRemove(ctx, req.(*BatchRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_WatchMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMenuRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PizzaLandServer).WatchMenu(m, &grpc.GenericServerStream[WatchMenuRequest, WatchMenuResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PizzaLand_WatchMenuServer = grpc.ServerStreamingServer[WatchMenuResponse]
+
+func _PizzaLand_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_GetWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).GetWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PizzaLandServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PizzaLand_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PizzaLandServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PizzaLand_ImportMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PizzaLandServer).ImportMenu(&grpc.GenericServerStream[ImportMenuRequest, ImportMenuResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PizzaLand_ImportMenuServer = grpc.ClientStreamingServer[ImportMenuRequest, ImportMenuResponse]
+
 // PizzaLand_ServiceDesc is the grpc.ServiceDesc for PizzaLand service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,7 +1151,90 @@ var PizzaLand_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveCategory",
 			Handler:    _PizzaLand_RemoveCategory_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _PizzaLand_Restore_Handler,
+		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _PizzaLand_ListDeleted_Handler,
+		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _PizzaLand_RestoreCategory_Handler,
+		},
+		{
+			MethodName: "ListDeletedCategories",
+			Handler:    _PizzaLand_ListDeletedCategories_Handler,
+		},
+		{
+			MethodName: "UpdatePizza",
+			Handler:    _PizzaLand_UpdatePizza_Handler,
+		},
+		{
+			MethodName: "BatchSave",
+			Handler:    _PizzaLand_BatchSave_Handler,
+		},
+		{
+			MethodName: "BatchUpdate",
+			Handler:    _PizzaLand_BatchUpdate_Handler,
+		},
+		{
+			MethodName: "BatchRemove",
+			Handler:    _PizzaLand_BatchRemove_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _PizzaLand_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _PizzaLand_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _PizzaLand_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _PizzaLand_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _PizzaLand_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _PizzaLand_GetWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _PizzaLand_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _PizzaLand_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _PizzaLand_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _PizzaLand_ListWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMenu",
+			Handler:       _PizzaLand_WatchMenu_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportMenu",
+			Handler:       _PizzaLand_ImportMenu_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pizzaland/pizzaland.proto",
 }
@@ -0,0 +1,7 @@
+// Package openapi embeds the OpenAPI v2 documents generated from the proto files by protoc-gen-openapiv2
+package openapi
+
+import _ "embed"
+
+//go:embed pizzaland/pizzaland.swagger.json
+var PizzaLand []byte
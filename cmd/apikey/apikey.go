@@ -0,0 +1,62 @@
+// Command apikey creates an API key right in the storage, so the first admin key exists before anyone can call CreateAPIKey
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+)
+
+var (
+	storagePath, label, scopes string
+	ttl                        time.Duration
+)
+
+func init() {
+	flag.StringVar(&storagePath, "storage-path", "", "Path to the database file")
+	flag.StringVar(&label, "label", "", "Human readable label of the key")
+	flag.StringVar(&scopes, "scopes", "", "Comma separated roles of the key")
+	flag.DurationVar(&ttl, "ttl", 0, "Lifetime of the key, the key never expires when it is zero")
+}
+
+func main() {
+	flag.Parse()
+
+	if storagePath == "" {
+		panic("storage path is required")
+	}
+	if label == "" || scopes == "" {
+		panic("label and scopes are required")
+	}
+
+	storage, err := sqlite.NewStorage(storagePath)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := storage.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	key, secret, err := apikeys.New(log, storage).Create(context.Background(), label, strings.Split(scopes, ","), expiresAt)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("created api key %d, the secret is shown only once:\n%s\n", key.ID, secret)
+}
@@ -1,18 +1,21 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"flag"
 	"fmt"
 
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	sqlite3migrate "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
 )
 
 var (
 	storagePath, migrationsPath, migrationsTable string
-	down                                         bool
+	down, checkNames                             bool
 )
 
 func init() {
@@ -20,23 +23,49 @@ func init() {
 	flag.StringVar(&migrationsPath, "migrations-path", "", "Path to a directory containing the migration files")
 	flag.StringVar(&migrationsTable, "migrations-table", "migrations", "Name of the migrations table in database")
 	flag.BoolVar(&down, "down", false, "Downgrade the database to the latest version")
+	flag.BoolVar(&checkNames, "check-names", false, "Only report pizzas and categories with conflicting names")
 }
 
 func main() {
 	flag.Parse()
 
+	if storagePath == "" {
+		panic("storage path is required")
+	}
+
+	// the migrations use the SQL functions registered by the application driver
+	db, err := sql.Open(sqlite.DriverName, storagePath)
+	if err != nil {
+		panic(err)
+	}
+
+	if !down {
+		conflicts, err := sqlite.FindDuplicateNames(context.Background(), db)
+		if err != nil {
+			panic(err)
+		}
+		for _, conflict := range conflicts {
+			fmt.Println(conflict)
+		}
+		if checkNames {
+			fmt.Printf("found %d name conflicts\n", len(conflicts))
+			return
+		}
+		if len(conflicts) != 0 {
+			panic(fmt.Sprintf("found %d name conflicts, rename or remove the pizzas and categories above first", len(conflicts)))
+		}
+	}
+
 	if migrationsPath == "" {
 		panic("migrations path is required")
 	}
 
-	if storagePath == "" {
-		panic("storage path is required")
+	driver, err := sqlite3migrate.WithInstance(db, &sqlite3migrate.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		panic(err)
 	}
 
-	m, err := migrate.New(
-		"file://"+migrationsPath,
-		"sqlite3://"+storagePath+"?x-migrations-table="+migrationsTable,
-	)
+	m, err := migrate.NewWithDatabaseInstance("file://"+migrationsPath, "sqlite3", driver)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,214 @@
+// Command pizzactl calls the PizzaLand service, for now to import the menu file:
+//
+//	pizzactl import --addr=127.0.0.1:44044 --dry-run menu.csv
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// chunkSize is the size of the file parts streamed to ImportMenu
+const chunkSize = 64 << 10
+
+var formats = map[string]pizzalndv1.MenuFileFormat{
+	"csv":  pizzalndv1.MenuFileFormat_MENU_FILE_CSV,
+	"json": pizzalndv1.MenuFileFormat_MENU_FILE_JSON,
+	"yaml": pizzalndv1.MenuFileFormat_MENU_FILE_YAML,
+	"yml":  pizzalndv1.MenuFileFormat_MENU_FILE_YAML,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "import":
+		os.Exit(importMenu(os.Args[2:]))
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: pizzactl import [flags] <menu file>")
+	os.Exit(2)
+}
+
+// importMenu streams the menu file to ImportMenu and prints the summary, it fails when any row failed
+func importMenu(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+
+	var (
+		addr    = fs.String("addr", "127.0.0.1:44044", "Address of the gRPC server")
+		format  = fs.String("format", "", "csv, json or yaml, taken from the file extension when empty")
+		dryRun  = fs.Bool("dry-run", false, "Check the rows and count the changes without writing them")
+		upsert  = fs.Bool("upsert", false, "Replace the categories and pizzas with the names of the rows")
+		token   = fs.String("token", os.Getenv("PIZZALAND_TOKEN"), "Bearer token of the SSO service, PIZZALAND_TOKEN by default")
+		apiKey  = fs.String("api-key", os.Getenv("PIZZALAND_API_KEY"), "API key used instead of the token, PIZZALAND_API_KEY by default")
+		useTLS  = fs.Bool("tls", false, "Connect over TLS")
+		caFile  = fs.String("ca-file", "", "CA certificate of the server, the system pool when empty")
+		timeout = fs.Duration("timeout", time.Minute, "Deadline of the import")
+	)
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+	path := fs.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	fileFormat, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown menu file format %q, use --format\n", *format)
+		return 2
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer file.Close()
+
+	creds, err := transportCredentials(*useTLS, *caFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch {
+	case *apiKey != "":
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", *apiKey)
+	case *token != "":
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+
+	res, err := send(ctx, pizzalndv1.NewPizzaLandClient(conn), file, &pizzalndv1.ImportMenuOptions{
+		Format: fileFormat,
+		DryRun: *dryRun,
+		Upsert: *upsert,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	for _, rowErr := range res.GetError() {
+		field := ""
+		if rowErr.GetField() != "" {
+			field = " " + rowErr.GetField() + ":"
+		}
+		fmt.Fprintf(os.Stderr, "%s:%d: row %d:%s %s\n", path, rowErr.GetLine(), rowErr.GetRow(), field, rowErr.GetMessage())
+	}
+
+	fmt.Printf(
+		"categories: %d created, %d updated; pizzas: %d created, %d updated; %d unchanged\n",
+		res.GetCategoriesCreated(), res.GetCategoriesUpdated(), res.GetPizzasCreated(), res.GetPizzasUpdated(), res.GetUnchanged(),
+	)
+
+	switch {
+	case len(res.GetError()) != 0:
+		fmt.Printf("%d errors, nothing was imported\n", len(res.GetError()))
+		return 1
+	case !res.GetApplied():
+		fmt.Println("dry run, nothing was imported")
+	default:
+		fmt.Println("imported")
+	}
+
+	return 0
+}
+
+// send streams the options and then the file in chunks
+func send(
+	ctx context.Context,
+	client pizzalndv1.PizzaLandClient,
+	file io.Reader,
+	options *pizzalndv1.ImportMenuOptions,
+) (*pizzalndv1.ImportMenuResponse, error) {
+	stream, err := client.ImportMenu(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&pizzalndv1.ImportMenuRequest{Part: &pizzalndv1.ImportMenuRequest_Options{Options: options}}); err != nil {
+		// the server already ended the call, its status is returned by CloseAndRecv
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return stream.CloseAndRecv()
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&pizzalndv1.ImportMenuRequest{Part: &pizzalndv1.ImportMenuRequest_Chunk{Chunk: buf[:n]}})
+			if errors.Is(sendErr, io.EOF) {
+				return stream.CloseAndRecv()
+			}
+			if sendErr != nil {
+				return nil, sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func transportCredentials(useTLS bool, caFile string) (credentials.TransportCredentials, error) {
+	if !useTLS && caFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + caFile)
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
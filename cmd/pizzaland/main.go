@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -24,14 +25,41 @@ func init() {
 }
 
 func main() {
+	os.Exit(run())
+}
+
+// run returns the exit code: 0 after a clean shutdown on a signal and 1 when anything failed
+func run() int {
 	log.Info("Starting pizzaland service", slog.Int("port", cfg.GRPC.Port))
 
-	application := app.MustLoadApp(log, cfg.GRPC.Port, cfg.StoragePath)
+	application := app.MustLoadApp(log, cfg)
 
-	go application.GRPCServer.MustStart()
+	errs := application.Start()
 
 	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	code := 0
+	select {
+	case s := <-sig:
+		log.Info("Stopping pizzaland service", slog.String("signal", s.String()))
+	case err := <-errs:
+		log.Error("Pizzaland service failed", slog.String("error", err.Error()))
+		code = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := application.Stop(ctx); err != nil {
+		log.Error("Pizzaland service stopped with errors", slog.String("error", err.Error()))
+		code = 1
+	}
+
+	log.Info("Pizzaland server stopped", slog.Int("exit_code", code))
+
+	// the logs are written straight to stdout, make sure they are out before the process exits
+	_ = os.Stdout.Sync()
 
-	log.Info("Pizzaland server stopped", slog.String("signal", (<-sig).String()))
+	return code
 }
@@ -0,0 +1,78 @@
+package adminapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	opStart = "adminapp.Start"
+	opStop  = "adminapp.Stop"
+)
+
+// App serves the operational endpoints on a port separate from the public ones
+type App struct {
+	log    *slog.Logger
+	server *http.Server
+}
+
+// NewApp serves the metrics gathered by gatherer at /metrics
+func NewApp(log *slog.Logger, cfg config.Admin, gatherer prometheus.Gatherer) *App {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+		// a failed collector drops its own metrics only
+		ErrorHandling: promhttp.ContinueOnError,
+		ErrorLog:      slog.NewLogLogger(log.Handler(), slog.LevelError),
+	}))
+
+	return &App{
+		log: log,
+		server: &http.Server{
+			Addr:              net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+			Handler:           mux,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		},
+	}
+}
+
+func (app *App) MustStart() {
+	if err := app.Start(); err != nil {
+		panic(err)
+	}
+}
+
+// Start serves the HTTP calls until Stop is called
+func (app *App) Start() error {
+	log := app.log.With(slog.String("op", opStart), slog.String("address", app.server.Addr))
+
+	l, err := net.Listen("tcp", app.server.Addr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	log.Info("Admin server started")
+
+	if err := app.server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	return nil
+}
+
+// Stop waits for the in-flight HTTP calls until ctx is done
+func (app *App) Stop(ctx context.Context) error {
+	if err := app.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("%s: %w", opStop, err)
+	}
+
+	return nil
+}
@@ -1,30 +1,306 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net/http"
+	"time"
 
+	"github.com/nhassl3/pizzaland/internals/app/adminapp"
+	"github.com/nhassl3/pizzaland/internals/app/gatewayapp"
 	"github.com/nhassl3/pizzaland/internals/app/grpcapp"
+	"github.com/nhassl3/pizzaland/internals/app/outboxapp"
+	"github.com/nhassl3/pizzaland/internals/app/purgeapp"
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso"
+	"github.com/nhassl3/pizzaland/internals/clients/sinks"
+	webhooksClient "github.com/nhassl3/pizzaland/internals/clients/webhooks"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
+	"github.com/nhassl3/pizzaland/internals/domain/services/outbox"
 	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/webhooks"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	"github.com/nhassl3/pizzaland/internals/lib/hub"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"github.com/nhassl3/pizzaland/internals/storage/metered"
 	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// the admin server and the trace exporter get their own time after the drain, which may use up the shutdown timeout
+const (
+	adminStopTimeout  = 2 * time.Second
+	traceFlushTimeout = 5 * time.Second
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
+	// Gateway is nil when the REST/JSON gateway is disabled
+	Gateway *gatewayapp.App
+	// Admin is nil when the admin server is disabled
+	Admin *adminapp.App
+	// PurgeJob is nil when the trash purge is disabled
+	PurgeJob *purgeapp.App
+	// Outbox is nil when the change events are not delivered
+	Outbox *outboxapp.App
+	// Webhooks is nil when the webhooks are not called
+	Webhooks *outboxapp.App
+	storage  *sqlite.Storage
+	tracing  *tracing.Provider
+	// sso is nil when the callers are not authenticated
+	sso *sso.Client
+	// sink is nil when the change events are not delivered
+	sink sinks.Sink
+	// webhookClient is nil when the webhooks are not called
+	webhookClient *webhooksClient.Client
+	menu          *pizzaland.MenuHub
 }
 
-func MustLoadApp(
-	log *slog.Logger,
-	gRPCPort int,
-	storagePath string,
-) *App {
-	storage, err := sqlite.NewStorage(storagePath)
+func MustLoadApp(log *slog.Logger, cfg *config.Config) *App {
+	tracingProvider, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		panic(err)
+	}
+
+	storage, err := sqlite.NewStorage(cfg.StoragePath)
+	if err != nil {
+		panic(err)
+	}
+
+	var (
+		pizzaStorage metered.Storage = storage
+		registry     *prometheus.Registry
+		// reg stays nil without the admin server, so nothing is measured
+		reg prometheus.Registerer
+	)
+	if cfg.Admin.Enabled {
+		registry = prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			metered.NewCollector(storage),
+		)
+		reg = registry
+
+		if pizzaStorage, err = metered.New(storage, reg); err != nil {
+			panic(err)
+		}
+	}
+
+	var (
+		ssoClient *sso.Client
+		// verifier stays nil without the SSO service, so the calls are not authenticated
+		verifier interceptors.TokenVerifier
+	)
+	if cfg.Clients.SSO.Enabled {
+		if ssoClient, err = sso.New(log, cfg.Clients.SSO); err != nil {
+			panic(err)
+		}
+		verifier = ssoClient
+	}
+
+	var (
+		sink sinks.Sink
+		// eventOutbox stays nil without the dispatcher, so no event is queued
+		eventOutbox pizzaland.Outbox
+	)
+	if cfg.Outbox.Enabled {
+		if sink, err = sinks.New(cfg.Outbox.Sink); err != nil {
+			panic(err)
+		}
+		eventOutbox = storage
+	}
+
+	// eventWebhooks stays nil without the deliverer, so no delivery is queued
+	var eventWebhooks pizzaland.Webhooks
+	if cfg.Webhooks.Enabled {
+		eventWebhooks = storage
+	}
+
+	// the changes are published under the ids of their audit entries, so the watchers resume across restarts
+	lastChange, err := storage.LastAuditEventId(context.Background())
 	if err != nil {
 		panic(err)
 	}
+	menu := hub.New[pizzaland.MenuChange](cfg.Watch.History, cfg.Watch.SubscriberBuffer, lastChange)
+
+	urlPizzaLandObj := pizzaland.NewPizzaLand(
+		log, pizzaStorage, pizzaStorage, pizzaStorage, pizzaStorage, storage, storage,
+		interceptors.Caller, eventOutbox, eventWebhooks, menu,
+	)
+	idempotencyObj := idempotency.New(log, storage, storage, cfg.Idempotency.TTL)
+	apiKeysObj := apikeys.New(log, storage)
+	webhooksObj := webhooks.New(log, storage)
+
+	application := &App{
+		GRPCServer: grpcapp.NewApp(log, cfg.GRPC, urlPizzaLandObj, idempotencyObj, storage, reg, verifier, apiKeysObj, webhooksObj),
+		storage:    storage,
+		tracing:    tracingProvider,
+		sso:        ssoClient,
+		sink:       sink,
+		menu:       menu,
+	}
+
+	if cfg.Admin.Enabled {
+		application.Admin = adminapp.NewApp(log, cfg.Admin, registry)
+	}
+
+	if cfg.Gateway.Enabled {
+		application.Gateway = gatewayapp.NewApp(log, cfg.Gateway, cfg.GRPC)
+	}
+
+	if cfg.Purge.Enabled {
+		application.PurgeJob = purgeapp.NewApp(log, urlPizzaLandObj, cfg.Purge.Interval, cfg.Purge.RetentionDays)
+	}
+
+	if cfg.Outbox.Enabled {
+		dispatcher := outbox.New(log, storage, sink, outbox.Options{
+			BatchSize:   cfg.Outbox.BatchSize,
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+			MinBackoff:  cfg.Outbox.MinBackoff,
+			MaxBackoff:  cfg.Outbox.MaxBackoff,
+		})
+		application.Outbox = outboxapp.NewApp(log, dispatcher, cfg.Outbox.Interval)
+	}
+
+	if cfg.Webhooks.Enabled {
+		application.webhookClient = webhooksClient.New(&http.Client{Timeout: cfg.Webhooks.Timeout})
+		deliverer := webhooks.NewDeliverer(log, storage, application.webhookClient, webhooks.Options{
+			BatchSize:    cfg.Webhooks.BatchSize,
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			DisableAfter: cfg.Webhooks.DisableAfter,
+			MinBackoff:   cfg.Webhooks.MinBackoff,
+			MaxBackoff:   cfg.Webhooks.MaxBackoff,
+		})
+		// the deliveries are queued like the outbox events, so they are polled the same way
+		application.Webhooks = outboxapp.NewApp(log.With(slog.String("component", "webhooks")), deliverer, cfg.Webhooks.Interval)
+	}
+
+	return application
+}
+
+// Start runs the gRPC server, the gateway, the admin server, the purge job, the outbox dispatcher
+// and the webhook deliverer in the background.
+// The error of the first one to fail is sent to the returned channel.
+func (a *App) Start() <-chan error {
+	errs := make(chan error, 6)
+
+	go func() {
+		if err := a.GRPCServer.Start(); err != nil {
+			errs <- err
+		}
+	}()
+
+	if a.Gateway != nil {
+		go func() {
+			if err := a.Gateway.Start(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	if a.Admin != nil {
+		go func() {
+			if err := a.Admin.Start(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	if a.PurgeJob != nil {
+		go func() {
+			if err := a.PurgeJob.Start(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	if a.Outbox != nil {
+		go func() {
+			if err := a.Outbox.Start(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	if a.Webhooks != nil {
+		go func() {
+			if err := a.Webhooks.Start(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	return errs
+}
+
+// Stop ends the menu watches, drains the gateway and the gRPC server until ctx is done, stops the purge job, the outbox dispatcher,
+// the webhook deliverer and the admin server, closes the sink, the storage and the SSO connection and flushes the traces.
+// The admin server and the trace flush are bounded by their own timeouts rather than by ctx.
+// The servers go first, so no call is left to see the closed storage.
+func (a *App) Stop(ctx context.Context) error {
+	var errs []error
+
+	// the watch streams never end on their own, so they are ended before the calls are drained
+	a.menu.Close()
+
+	if a.Gateway != nil {
+		if err := a.Gateway.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := a.GRPCServer.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if a.PurgeJob != nil {
+		a.PurgeJob.Stop()
+	}
 
-	urlPizzaLandObj := pizzaland.NewPizzaLand(log, storage, storage, storage, storage)
+	if a.Outbox != nil {
+		a.Outbox.Stop()
+	}
+	if a.sink != nil {
+		if err := a.sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Webhooks != nil {
+		a.Webhooks.Stop()
+		a.webhookClient.Close()
+	}
 
-	return &App{
-		GRPCServer: grpcapp.NewApp(log, gRPCPort, urlPizzaLandObj),
+	// the metrics stay available until the calls are drained
+	if a.Admin != nil {
+		adminCtx, cancel := context.WithTimeout(context.Background(), adminStopTimeout)
+		if err := a.Admin.Stop(adminCtx); err != nil {
+			errs = append(errs, err)
+		}
+		cancel()
 	}
+
+	if err := a.storage.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if a.sso != nil {
+		if err := a.sso.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// the spans of the drained calls are flushed last
+	flushCtx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+
+	if err := a.tracing.Shutdown(flushCtx); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,175 @@
+package gatewayapp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/api/generated/openapi"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	opNew   = "gatewayapp.NewApp"
+	opStart = "gatewayapp.Start"
+	opStop  = "gatewayapp.Stop"
+)
+
+// forwardedHeaders are passed between HTTP and gRPC as is, the rest goes through the Grpc-Metadata- prefix
+var forwardedHeaders = []string{"x-request-id", "idempotency-key", "traceparent", "tracestate", "x-api-key"}
+
+// App serves the REST/JSON gateway which translates HTTP calls into the calls of the gRPC server
+type App struct {
+	log    *slog.Logger
+	server *http.Server
+	conn   *grpc.ClientConn
+}
+
+// NewApp builds the gateway in front of the gRPC server configured by gRPC.
+// The calls go through the gRPC server, so they pass the same interceptors as the native ones.
+// The address of the HTTP caller is appended to the x-forwarded-for metadata, which the gRPC server
+// reads for the rate limits and the invalid API key limits when the gateway is one of its trusted proxies.
+func NewApp(log *slog.Logger, cfg config.Gateway, gRPC config.GRPC) *App {
+	creds, err := transportCredentials(cfg, gRPC)
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", opNew, err))
+	}
+
+	conn, err := grpc.NewClient(dialAddress(gRPC), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", opNew, err))
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(headerMatcher),
+		// errors are written as the JSON google.rpc.Status objects
+		runtime.WithErrorHandler(runtime.DefaultHTTPErrorHandler),
+	)
+
+	if err := pizzalndv1.RegisterPizzaLandHandler(context.Background(), mux, conn); err != nil {
+		panic(fmt.Errorf("%s: %w", opNew, err))
+	}
+
+	if err := mux.HandlePath(http.MethodGet, "/openapi.json", serveOpenAPI); err != nil {
+		panic(fmt.Errorf("%s: %w", opNew, err))
+	}
+
+	return &App{
+		log:  log,
+		conn: conn,
+		server: &http.Server{
+			Addr:              net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+			Handler:           mux,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		},
+	}
+}
+
+func (app *App) MustStart() {
+	if err := app.Start(); err != nil {
+		panic(err)
+	}
+}
+
+// Start serves the HTTP calls until Stop is called
+func (app *App) Start() error {
+	log := app.log.With(slog.String("op", opStart), slog.String("address", app.server.Addr))
+
+	l, err := net.Listen("tcp", app.server.Addr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	log.Info("Gateway started")
+
+	if err := app.server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	return nil
+}
+
+// Stop waits for the in-flight HTTP calls until ctx is done and closes the connection to the gRPC server
+func (app *App) Stop(ctx context.Context) error {
+	err := app.server.Shutdown(ctx)
+	if closeErr := app.conn.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", opStop, err)
+	}
+
+	return nil
+}
+
+func headerMatcher(key string) (string, bool) {
+	for _, header := range forwardedHeaders {
+		if strings.EqualFold(key, header) {
+			return header, true
+		}
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openapi.PizzaLand)
+}
+
+// dialAddress points the gateway to the gRPC server, the wildcard listen host is reached through loopback
+func dialAddress(gRPC config.GRPC) string {
+	host := gRPC.Host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(gRPC.Port))
+}
+
+// transportCredentials mirrors the TLS setup of the gRPC server
+func transportCredentials(cfg config.Gateway, gRPC config.GRPC) (credentials.TransportCredentials, error) {
+	if gRPC.TLS.CertFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + cfg.CAFile)
+		}
+	}
+
+	if cfg.CertFile != "" {
+		pair, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
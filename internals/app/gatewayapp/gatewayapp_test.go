@@ -0,0 +1,69 @@
+package gatewayapp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedFor records the x-forwarded-for metadata of the List calls
+type forwardedFor struct {
+	pizzalndv1.UnimplementedPizzaLandServer
+	got chan []string
+}
+
+func (f *forwardedFor) List(ctx context.Context, _ *pizzalndv1.ListRequest) (*pizzalndv1.ListResponse, error) {
+	f.got <- metadata.ValueFromIncomingContext(ctx, "x-forwarded-for")
+	return &pizzalndv1.ListResponse{}, nil
+}
+
+func TestForwardedFor(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	backend := &forwardedFor{got: make(chan []string, 1)}
+	pizzalndv1.RegisterPizzaLandServer(server, backend)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	app := NewApp(slog.New(slog.NewTextHandler(io.Discard, nil)), config.Gateway{}, config.GRPC{
+		Host: "127.0.0.1",
+		Port: lis.Addr().(*net.TCPAddr).Port,
+	})
+	t.Cleanup(func() { _ = app.conn.Close() })
+
+	gateway := httptest.NewServer(app.server.Handler)
+	t.Cleanup(gateway.Close)
+
+	req, err := http.NewRequest(http.MethodGet, gateway.URL+"/v1/pizzas", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the header set by the caller is kept, the gateway appends the address it saw
+	req.Header.Set("X-Forwarded-For", "198.51.100.7")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+
+	if got := <-backend.got; len(got) != 1 || got[0] != "198.51.100.7, 127.0.0.1" {
+		t.Errorf("x-forwarded-for %q, want the caller address last", got)
+	}
+}
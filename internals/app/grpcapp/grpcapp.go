@@ -1,53 +1,322 @@
 package grpcapp
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
 
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
 	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/webhooks"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
 	pizzaLandGRPC "github.com/nhassl3/pizzaland/internals/grpc/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/lib/certs"
+	"github.com/nhassl3/pizzaland/internals/lib/ratelimit"
+	"github.com/nhassl3/pizzaland/internals/lib/rbac"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-const opStart = "grpcapp.MustStart"
+const (
+	opStart = "grpcapp.Start"
+	opStop  = "grpcapp.Stop"
+)
 
 type App struct {
 	log        *slog.Logger
 	gRPCServer *grpc.Server
+	health     *health.Server
+	pinger     Pinger
+	interval   time.Duration
+	host       string
 	port       int
+	tls        bool
+	stop       chan struct{}
+	stopOnce   sync.Once
+
+	// webServer serves gRPCServer through ServeHTTP when gRPC-Web and Connect are enabled
+	webServer *http.Server
+	webTLS    *tls.Config
+	webCalls  *inflight
 }
 
 func NewApp(log *slog.Logger,
-	gRPCPort int,
+	cfg config.GRPC,
 	pizzaLandObj *pizzaland.DomainPizzaLand,
+	idempotencyObj *idempotency.Idempotency,
+	pinger Pinger,
+	reg prometheus.Registerer,
+	verifier interceptors.TokenVerifier,
+	apiKeysObj *apikeys.APIKeys,
+	webhooksObj *webhooks.Webhooks,
 ) *App {
-	gRPCServer := grpc.NewServer()
+	unary, stream, err := interceptors.Chain(log, cfg.Interceptors)
+	if err != nil {
+		panic(err)
+	}
+
+	// the callers behind the proxies are told apart by the address the proxies forward
+	proxies, err := trustedProxies(cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	client := func(ctx context.Context) string {
+		return interceptors.ClientKey(ctx, proxies)
+	}
+
+	// verifier is nil when the callers are not authenticated by the SSO tokens
+	if verifier != nil || cfg.APIKeys.Enabled {
+		var keys interceptors.KeyVerifier
+		if cfg.APIKeys.Enabled {
+			keys = apiKeysObj
+		}
+
+		auth := interceptors.Auth(log, verifier, keys, proxies)
+		unary, stream = append(unary, auth.Unary), append(stream, auth.Stream)
+	}
+
+	// the limits follow the authentication, so the callers are told apart by the subject
+	if cfg.RateLimit.Enabled {
+		rateLimit := interceptors.RateLimit(rateLimits(cfg.RateLimit, proxies))
+		unary, stream = append(unary, rateLimit.Unary), append(stream, rateLimit.Stream)
+	}
+
+	if cfg.RBAC.Enabled {
+		policy, err := rbac.NewPolicyFile(log, cfg.RBAC.PolicyFile, methodNames(pizzalndv1.PizzaLand_ServiceDesc))
+		if err != nil {
+			panic(err)
+		}
+
+		access := interceptors.RBAC(log, policy, pizzaLandObj)
+		unary, stream = append(unary, access.Unary), append(stream, access.Stream)
+	}
+
+	// every call gets the server-side deadline whatever the configured chain is
+	deadline := interceptors.Deadline(cfg.Timeout, cfg.MethodTimeouts)
+	unary, stream = append(unary, deadline.Unary), append(stream, deadline.Stream)
+
+	// reg is nil when the metrics are disabled
+	if reg != nil {
+		metrics, err := interceptors.Metrics(reg)
+		if err != nil {
+			panic(err)
+		}
 
-	pizzaLandGRPC.Register(gRPCServer, pizzaLandObj)
+		// the outermost interceptor sees the final status of every call, the recovered panics included
+		unary = append([]grpc.UnaryServerInterceptor{metrics.Unary}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{metrics.Stream}, stream...)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	var webTLS *tls.Config
+	if cfg.TLS.CertFile != "" || cfg.TLS.KeyFile != "" || cfg.TLS.ClientCAFile != "" {
+		reloader, err := certs.NewReloader(log, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			panic(err)
+		}
+
+		if cfg.Web.Enabled {
+			// the browsers may speak gRPC-Web over HTTP/1.1
+			webTLS = reloader.TLSConfig("h2", "http/1.1")
+		} else {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+		}
+	}
+
+	gRPCServer := grpc.NewServer(opts...)
+
+	pizzaLandGRPC.Register(gRPCServer, pizzaLandObj, idempotencyObj, client, apiKeysObj, webhooksObj)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
 
-	return &App{
+	if cfg.Reflection {
+		reflection.Register(gRPCServer)
+	}
+
+	app := &App{
 		gRPCServer: gRPCServer,
-		port:       gRPCPort,
+		health:     healthServer,
+		pinger:     pinger,
+		interval:   cfg.HealthInterval,
+		host:       cfg.Host,
+		port:       cfg.Port,
+		tls:        cfg.TLS.CertFile != "",
 		log:        log,
+		stop:       make(chan struct{}),
 	}
+
+	if cfg.Web.Enabled {
+		app.webCalls, app.webTLS = newInflight(), webTLS
+		if app.webServer, err = newWebServer(cfg.Web, gRPCServer, app.webCalls); err != nil {
+			panic(err)
+		}
+	}
+
+	return app
 }
 
 func (app *App) MustStart() {
-	log := app.log.With(slog.String("op", opStart), slog.Int("port", app.port))
+	if err := app.Start(); err != nil {
+		panic(err)
+	}
+}
 
-	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", app.port))
+// Start serves the calls until Stop is called
+func (app *App) Start() error {
+	log := app.log.With(
+		slog.String("op", opStart),
+		slog.Int("port", app.port),
+		slog.Bool("tls", app.tls),
+		slog.Bool("web", app.webServer != nil),
+	)
+
+	l, err := net.Listen("tcp", net.JoinHostPort(app.host, strconv.Itoa(app.port)))
 	if err != nil {
-		panic(fmt.Errorf("%s: %w", opStart, err))
+		return fmt.Errorf("%s: %w", opStart, err)
 	}
 
 	log.Info("Server started", slog.String("address", l.Addr().String()))
 
+	go app.watchHealth()
+
+	if app.webServer != nil {
+		if app.webTLS != nil {
+			l = tls.NewListener(l, app.webTLS)
+		}
+
+		if err := app.webServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("%s: %w", opStart, err)
+		}
+
+		return nil
+	}
+
 	if err := app.gRPCServer.Serve(l); err != nil {
-		panic(fmt.Errorf("%s: %w", opStart, err))
+		return fmt.Errorf("%s: %w", opStart, err)
+	}
+
+	return nil
+}
+
+// Stop reports NOT_SERVING to the health clients and waits for the in-flight calls until ctx is done.
+// The calls still running by then are cancelled and the error of ctx is returned.
+func (app *App) Stop(ctx context.Context) error {
+	app.stopOnce.Do(func() {
+		close(app.stop)
+		app.health.Shutdown()
+	})
+
+	if app.webServer != nil {
+		return app.stopWeb(ctx)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		app.gRPCServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		app.log.Warn("in-flight calls did not finish in time, cancelling them", slog.String("op", opStop))
+		app.gRPCServer.Stop()
+		<-stopped
+
+		return fmt.Errorf("%s: %w", opStop, ctx.Err())
+	}
+}
+
+// stopWeb stops accepting the calls and waits for the in-flight ones until ctx is done.
+// GracefulStop of the gRPC server can not drain the calls served through ServeHTTP, so Stop is used after the wait.
+func (app *App) stopWeb(ctx context.Context) error {
+	err := app.webServer.Shutdown(ctx)
+	if err == nil {
+		select {
+		case <-app.webCalls.done():
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	if err != nil {
+		app.log.Warn("in-flight calls did not finish in time, cancelling them", slog.String("op", opStop))
+		_ = app.webServer.Close()
+	}
+	app.gRPCServer.Stop()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", opStop, err)
+	}
+
+	return nil
+}
+
+func rateLimits(cfg config.RateLimit, proxies []netip.Prefix) interceptors.RateLimits {
+	methods := make(map[string]ratelimit.Budget, len(cfg.Methods))
+	for method, budget := range cfg.Methods {
+		methods[method] = ratelimit.Budget{Rate: budget.Rate, Burst: budget.Burst}
+	}
+
+	return interceptors.RateLimits{
+		Default:           ratelimit.Budget{Rate: cfg.Rate, Burst: cfg.Burst},
+		Methods:           methods,
+		MaxInFlightWrites: cfg.MaxInFlightWrites,
+		WriteRetryAfter:   cfg.WriteRetryAfter,
+		TrustedProxies:    proxies,
 	}
 }
 
-func (app *App) Stop() {
-	app.gRPCServer.GracefulStop()
+// trustedProxies parses the address ranges of the proxies, a bare address is a range of its own
+func trustedProxies(entries []string) ([]netip.Prefix, error) {
+	const op = "grpcapp.trustedProxies"
+
+	proxies := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(entry)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%s: trusted proxy %q: %w", op, entry, err)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+
+	return proxies, nil
+}
+
+// methodNames returns the bare names of the methods of the service
+func methodNames(desc grpc.ServiceDesc) []string {
+	names := make([]string, 0, len(desc.Methods)+len(desc.Streams))
+	for _, method := range desc.Methods {
+		names = append(names, method.MethodName)
+	}
+	for _, stream := range desc.Streams {
+		names = append(names, stream.StreamName)
+	}
+
+	return names
 }
@@ -0,0 +1,61 @@
+package grpcapp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const opWatchHealth = "grpcapp.watchHealth"
+
+// Pinger checks that the storage is able to serve queries
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// watchHealth pings the storage on every interval tick and flips the health status accordingly
+func (app *App) watchHealth() {
+	log := app.log.With(slog.String("op", opWatchHealth))
+
+	interval := app.interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	current := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		status := app.check(interval)
+		if status != current {
+			// the empty service name stands for the server as a whole
+			app.health.SetServingStatus("", status)
+			app.health.SetServingStatus(pizzalndv1.PizzaLand_ServiceDesc.ServiceName, status)
+
+			log.Info("health status changed", slog.String("status", status.String()))
+			current = status
+		}
+
+		select {
+		case <-app.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (app *App) check(timeout time.Duration) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := app.pinger.Ping(ctx); err != nil {
+		app.log.Warn("storage ping failed", slog.String("op", opWatchHealth), slog.String("error", err.Error()))
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,217 @@
+package grpcapp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/lib/certs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type okPinger struct{}
+
+func (okPinger) Ping(context.Context) error { return nil }
+
+func TestTLS(t *testing.T) {
+	files := writeCerts(t, t.TempDir())
+	addr := startApp(t, config.TLS{CertFile: files.CertFile, KeyFile: files.KeyFile})
+
+	if err := check(t, addr, credentials.NewTLS(clientTLS(t, files.CAFile, nil))); err != nil {
+		t.Fatalf("health check over TLS: %v", err)
+	}
+
+	if err := check(t, addr, insecure.NewCredentials()); err == nil {
+		t.Fatal("plain text client was accepted")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	files := writeCerts(t, t.TempDir())
+	addr := startApp(t, config.TLS{CertFile: files.CertFile, KeyFile: files.KeyFile, ClientCAFile: files.CAFile})
+
+	pair, err := tls.LoadX509KeyPair(files.ClientCertFile, files.ClientKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := check(t, addr, credentials.NewTLS(clientTLS(t, files.CAFile, &pair))); err != nil {
+		t.Fatalf("health check with the client certificate: %v", err)
+	}
+
+	if err := check(t, addr, credentials.NewTLS(clientTLS(t, files.CAFile, nil))); err == nil {
+		t.Fatal("client without a certificate was accepted")
+	}
+}
+
+func TestCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	old := writeCerts(t, dir)
+	oldCA := readFile(t, old.CAFile)
+
+	addr := startApp(t, config.TLS{CertFile: old.CertFile, KeyFile: old.KeyFile})
+
+	if err := check(t, addr, credentials.NewTLS(clientTLS(t, old.CAFile, nil))); err != nil {
+		t.Fatalf("health check before the reload: %v", err)
+	}
+
+	// the files are replaced in place by a certificate of another CA, the reloader looks at them at most once a second
+	time.Sleep(1100 * time.Millisecond)
+	renewed := writeCerts(t, dir)
+
+	oldCAFile := filepath.Join(dir, "old-ca.pem")
+	if err := os.WriteFile(oldCAFile, oldCA, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for check(t, addr, credentials.NewTLS(clientTLS(t, renewed.CAFile, nil))) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("the renewed certificate was not picked up")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	if err := check(t, addr, credentials.NewTLS(clientTLS(t, oldCAFile, nil))); err == nil {
+		t.Fatal("the replaced certificate is still served")
+	}
+}
+
+func writeCerts(t *testing.T, dir string) certs.Files {
+	t.Helper()
+
+	files, err := certs.WriteSelfSigned(dir, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+// startApp serves the health service on a free loopback port with the TLS setup and returns the address
+func startApp(t *testing.T, tlsCfg config.TLS) string {
+	t.Helper()
+
+	cfg := testConfig(t)
+	cfg.TLS = tlsCfg
+
+	return serve(t, NewApp(discardLogger(), cfg, nil, nil, okPinger{}, nil, nil, nil, nil), cfg)
+}
+
+// testConfig listens on a free loopback port
+func testConfig(t *testing.T) config.GRPC {
+	t.Helper()
+
+	return config.GRPC{
+		Host:           "127.0.0.1",
+		Port:           freePort(t),
+		Timeout:        5 * time.Second,
+		HealthInterval: time.Second,
+	}
+}
+
+// serve starts the app, stops it with the test and returns its address once it accepts connections
+func serve(t *testing.T, app *App, cfg config.GRPC) string {
+	t.Helper()
+
+	done := make(chan error, 1)
+	go func() { done <- app.Start() }()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := app.Stop(ctx); err != nil {
+			t.Errorf("stop: %v", err)
+		}
+		<-done
+	})
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			return addr
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not start: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// check calls the health service over a fresh connection, so every call makes a new handshake
+func check(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	return err
+}
+
+func clientTLS(t *testing.T, caFile string, pair *tls.Certificate) *tls.Config {
+	t.Helper()
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(readFile(t, caFile)) {
+		t.Fatalf("no certificates in %s", caFile)
+	}
+
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if pair != nil {
+		cfg.Certificates = []tls.Certificate{*pair}
+	}
+
+	return cfg
+}
+
+func readFile(t *testing.T, file string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,227 @@
+package grpcapp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID  = "00f067aa0ba902b7"
+)
+
+// syncBuffer collects the log records written by the concurrent calls
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestTracing(t *testing.T) {
+	// the recorder becomes the global provider, so it is installed once for the whole package
+	rec := tracing.NewRecorder()
+	if _, err := tracing.Setup(context.Background(), config.Tracing{}); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := &syncBuffer{}
+	log := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	storage := sqlitetest.New(t)
+	pizzaLandObj := pizzaland.NewPizzaLand(
+		log, storage, storage, storage, storage, storage, storage,
+		interceptors.Caller, nil, nil, nil,
+	)
+
+	cfg := testConfig(t)
+	cfg.Interceptors = []string{"request_id", "tracing", "logging", "recovery"}
+	addr := serve(t, NewApp(log, cfg, pizzaLandObj, idempotency.New(log, storage, storage, time.Hour), okPinger{}, nil, nil, nil, nil), cfg)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "traceparent", "00-"+incomingTraceID+"-"+incomingSpanID+"-01")
+
+	client := pizzalndv1.NewPizzaLandClient(conn)
+	if _, err := client.SaveCategory(ctx, &pizzalndv1.SaveCategoryRequest{
+		Category: &pizzalndv1.CategoryProperties{Name: "Classic"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("continues the incoming trace", func(t *testing.T) {
+		server := onlySpan(t, rec, pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/SaveCategory")
+
+		if got := server.SpanContext().TraceID().String(); got != incomingTraceID {
+			t.Errorf("trace id %s, want %s", got, incomingTraceID)
+		}
+		if got := server.Parent().SpanID().String(); got != incomingSpanID || !server.Parent().IsRemote() {
+			t.Errorf("parent span %s (remote %t), want the remote %s", got, server.Parent().IsRemote(), incomingSpanID)
+		}
+		if server.SpanKind() != trace.SpanKindServer {
+			t.Errorf("span kind %s, want server", server.SpanKind())
+		}
+	})
+
+	t.Run("nests the domain and the sqlite spans", func(t *testing.T) {
+		server := onlySpan(t, rec, pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/SaveCategory")
+		domain := onlySpan(t, rec, "pizzaland.SaveCategory")
+
+		if domain.Parent().SpanID() != server.SpanContext().SpanID() {
+			t.Errorf("the domain span is not a child of the server span")
+		}
+
+		var insert, selects []sdktrace.ReadOnlySpan
+		for _, span := range rec.Ended() {
+			if span.SpanContext().TraceID() != server.SpanContext().TraceID() {
+				continue
+			}
+			switch span.Name() {
+			case "sqlite INSERT":
+				if strings.Contains(attr(span, "db.statement"), "INSERT INTO categories") {
+					insert = append(insert, span)
+				}
+			case "sqlite SELECT":
+				selects = append(selects, span)
+			}
+		}
+
+		if len(insert) != 1 {
+			t.Fatalf("%d category inserts recorded, want 1", len(insert))
+		}
+		if !descends(rec, insert[0], domain) {
+			t.Error("the category insert is not under the domain span")
+		}
+		if got := attr(insert[0], "db.rows_affected"); got != "1" {
+			t.Errorf("db.rows_affected %q, want 1", got)
+		}
+
+		if len(selects) == 0 {
+			t.Fatal("no select recorded")
+		}
+		for _, span := range selects {
+			if !descends(rec, span, domain) {
+				t.Errorf("the select %q is not under the domain span", attr(span, "db.statement"))
+			}
+			if attr(span, "db.rows_returned") == "" {
+				t.Errorf("the select %q has no db.rows_returned", attr(span, "db.statement"))
+			}
+		}
+	})
+
+	t.Run("correlates the log records", func(t *testing.T) {
+		server := onlySpan(t, rec, pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/SaveCategory")
+
+		var found []string
+		scanner := bufio.NewScanner(strings.NewReader(logs.String()))
+		for scanner.Scan() {
+			var record map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				t.Fatalf("log record %q: %v", scanner.Text(), err)
+			}
+			if record["trace_id"] != incomingTraceID {
+				continue
+			}
+			if record["span_id"] != server.SpanContext().SpanID().String() {
+				t.Errorf("record %q has the span id %v, want the server span", record["msg"], record["span_id"])
+			}
+			found = append(found, record["msg"].(string))
+		}
+
+		for _, msg := range []string{"category saved", "call finished"} {
+			if !contains(found, msg) {
+				t.Errorf("no %q record with the trace id, got %q", msg, found)
+			}
+		}
+	})
+}
+
+func onlySpan(t *testing.T, rec *tracing.Recorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	spans := rec.Named(name)
+	if len(spans) != 1 {
+		t.Fatalf("%d spans named %q, want 1", len(spans), name)
+	}
+
+	return spans[0]
+}
+
+// descends tells whether the span is under the ancestor, following the parents through the recorded spans
+func descends(rec *tracing.Recorder, span, ancestor sdktrace.ReadOnlySpan) bool {
+	byID := make(map[trace.SpanID]sdktrace.ReadOnlySpan)
+	for _, s := range rec.Ended() {
+		byID[s.SpanContext().SpanID()] = s
+	}
+
+	for parent := span.Parent().SpanID(); parent.IsValid(); {
+		if parent == ancestor.SpanContext().SpanID() {
+			return true
+		}
+		next, ok := byID[parent]
+		if !ok {
+			return false
+		}
+		parent = next.Parent().SpanID()
+	}
+
+	return false
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) string {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package grpcapp
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	"github.com/nhassl3/pizzaland/internals/grpc/web"
+	"github.com/rs/cors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// webHeaders are the request headers the gRPC-Web and Connect clients send
+var webHeaders = []string{
+	"Content-Type",
+	"Content-Encoding",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"Connect-Content-Encoding",
+	"Connect-Accept-Encoding",
+	"Grpc-Timeout",
+	"X-Grpc-Web",
+	"X-User-Agent",
+	interceptors.RequestIdHeader,
+	"Idempotency-Key",
+	"Authorization",
+	interceptors.APIKeyHeader,
+}
+
+// webExposedHeaders are the response headers the browser clients read
+var webExposedHeaders = []string{
+	"Grpc-Status",
+	"Grpc-Message",
+	"Grpc-Status-Details-Bin",
+	interceptors.RequestIdHeader,
+}
+
+// newWebServer serves the native gRPC, gRPC-Web and Connect calls with gRPCServer on a single port
+func newWebServer(cfg config.Web, gRPCServer *grpc.Server, calls *inflight) (*http.Server, error) {
+	handler := calls.track(web.NewHandler(gRPCServer))
+
+	// without any allowed origin only the same-origin clients are served, so no CORS headers are needed
+	if len(cfg.CORS.AllowedOrigins) != 0 {
+		handler = cors.New(cors.Options{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+			AllowedHeaders:   append(append([]string(nil), webHeaders...), cfg.CORS.AllowedHeaders...),
+			ExposedHeaders:   webExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           int(cfg.CORS.MaxAge.Seconds()),
+		}).Handler(handler)
+	}
+
+	h2Server := &http2.Server{}
+	server := &http.Server{
+		Handler:           h2c.NewHandler(handler, h2Server),
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+	}
+
+	// the HTTP/2 connections, including the h2c ones, get GOAWAY on Shutdown
+	if err := http2.ConfigureServer(server, h2Server); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// inflight counts the running calls, since Shutdown of the HTTP server does not wait for the h2c connections
+type inflight struct {
+	mu sync.Mutex
+	n  int
+	// idle is closed while no call is running
+	idle chan struct{}
+}
+
+func newInflight() *inflight {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &inflight{idle: idle}
+}
+
+func (f *inflight) track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.add(1)
+		defer f.add(-1)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *inflight) add(delta int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.n == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.n += delta
+	if f.n == 0 {
+		close(f.idle)
+	}
+}
+
+// done is closed once no call is running
+func (f *inflight) done() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.idle
+}
@@ -0,0 +1,100 @@
+package outboxapp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const opStart = "outboxapp.Start"
+
+// Dispatcher delivers the due events of the next batch of the outbox
+type Dispatcher interface {
+	Dispatch(ctx context.Context) (delivered int, more bool, err error)
+}
+
+type App struct {
+	log        *slog.Logger
+	dispatcher Dispatcher
+	interval   time.Duration
+	// ctx is cancelled by Stop, so the running delivery is abandoned
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewApp(
+	log *slog.Logger,
+	dispatcher Dispatcher,
+	interval time.Duration,
+) *App {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &App{
+		log:        log,
+		dispatcher: dispatcher,
+		interval:   interval,
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+}
+
+func (app *App) MustStart() {
+	if err := app.Start(); err != nil {
+		panic(err)
+	}
+}
+
+// Start dispatches the outbox until it is drained and then on every interval tick until Stop is called
+func (app *App) Start() error {
+	defer close(app.done)
+
+	log := app.log.With(slog.String("op", opStart), slog.Duration("interval", app.interval))
+
+	if app.interval <= 0 {
+		return errors.New(opStart + ": outbox interval must be positive")
+	}
+
+	log.Info("Outbox dispatcher started")
+
+	ticker := time.NewTicker(app.interval)
+	defer ticker.Stop()
+
+	for {
+		for app.dispatch(log) {
+		}
+
+		select {
+		case <-app.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop abandons the running dispatch and waits for it, the events it has not delivered stay queued
+func (app *App) Stop() {
+	app.cancel()
+	<-app.done
+}
+
+// dispatch reports whether more events are ready right away
+func (app *App) dispatch(log *slog.Logger) bool {
+	delivered, more, err := app.dispatcher.Dispatch(app.ctx)
+	switch {
+	case app.ctx.Err() != nil:
+		// stopped, the failure is the abandoned delivery
+		return false
+	case err != nil:
+		log.Error("Outbox dispatch failed", slog.String("error", err.Error()))
+		return false
+	}
+
+	if delivered > 0 {
+		log.Debug("Events delivered", slog.Int("events", delivered))
+	}
+
+	return more
+}
@@ -0,0 +1,94 @@
+package purgeapp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const opStart = "purgeapp.Start"
+
+// Purger hard-deletes the trash content older than retention
+type Purger interface {
+	Purge(ctx context.Context, retention time.Duration) (pizzas, categories int64, err error)
+}
+
+type App struct {
+	log       *slog.Logger
+	purger    Purger
+	interval  time.Duration
+	retention time.Duration
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewApp(
+	log *slog.Logger,
+	purger Purger,
+	interval time.Duration,
+	retentionDays int,
+) *App {
+	return &App{
+		log:       log,
+		purger:    purger,
+		interval:  interval,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (app *App) MustStart() {
+	if err := app.Start(); err != nil {
+		panic(err)
+	}
+}
+
+// Start runs the purge on every interval tick until Stop is called
+func (app *App) Start() error {
+	defer close(app.done)
+
+	log := app.log.With(
+		slog.String("op", opStart),
+		slog.Duration("interval", app.interval),
+		slog.Duration("retention", app.retention),
+	)
+
+	if app.interval <= 0 {
+		return errors.New(opStart + ": purge interval must be positive")
+	}
+
+	log.Info("Purge job started")
+
+	ticker := time.NewTicker(app.interval)
+	defer ticker.Stop()
+
+	for {
+		app.purge(log)
+
+		select {
+		case <-app.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (app *App) Stop() {
+	close(app.stop)
+	<-app.done
+}
+
+func (app *App) purge(log *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), app.interval)
+	defer cancel()
+
+	pizzas, categories, err := app.purger.Purge(ctx, app.retention)
+	if err != nil {
+		log.Error("Purge failed", slog.String("error", err.Error()))
+		return
+	}
+
+	log.Info("Trash purged", slog.Int64("pizzas", pizzas), slog.Int64("categories", categories))
+}
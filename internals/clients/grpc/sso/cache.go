@@ -0,0 +1,68 @@
+package sso
+
+import (
+	"sync"
+	"time"
+)
+
+// cache keeps the answers of the service for ttl, a zero ttl turns it off
+type cache[V any] struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry[V]
+	swept   time.Time
+}
+
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func newCache[V any](ttl time.Duration) *cache[V] {
+	return &cache[V]{ttl: ttl, entries: make(map[string]cacheEntry[V]), swept: time.Now()}
+}
+
+func (c *cache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+// set keeps the value for ttl or until expiresAt when it comes earlier, a zero expiresAt is ignored
+func (c *cache[V]) set(key string, value V, expiresAt time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := time.Now()
+	if deadline := now.Add(c.ttl); expiresAt.IsZero() || deadline.Before(expiresAt) {
+		expiresAt = deadline
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep(now)
+	c.entries[key] = cacheEntry[V]{value: value, expiresAt: expiresAt}
+}
+
+// sweep drops the expired entries, at most once per ttl
+func (c *cache[V]) sweep(now time.Time) {
+	if now.Sub(c.swept) < c.ttl {
+		return
+	}
+	c.swept = now
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+const (
+	// minRefetch limits the fetches of the keys caused by unknown key ids, so forged tokens cannot flood the service
+	minRefetch = time.Minute
+	// leeway tolerates the clock skew between the hosts
+	leeway = 30 * time.Second
+)
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// signingMethods are the asymmetric algorithms, the keys of a JWKS cannot verify anything else
+var signingMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// claims are the claims of the access tokens issued by the SSO service
+type claims struct {
+	jwt.RegisteredClaims
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+func (c *Client) verifyLocally(ctx context.Context, token string) (models.Identity, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if c.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.issuer))
+	}
+	if c.audience != "" {
+		opts = append(opts, jwt.WithAudience(c.audience))
+	}
+
+	// keysErr tells the keys the service failed to send from the tokens signed by unknown keys
+	var (
+		parsed  claims
+		keysErr error
+	)
+	_, err := jwt.ParseWithClaims(token, &parsed, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		key, err := c.keys.key(ctx, kid)
+		if err != nil && !errors.Is(err, errUnknownKey) {
+			keysErr = err
+		}
+
+		return key, err
+	}, opts...)
+	if keysErr != nil {
+		return models.Identity{}, keysErr
+	}
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if parsed.Subject == "" {
+		return models.Identity{}, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	return models.Identity{
+		Subject:   parsed.Subject,
+		Email:     parsed.Email,
+		Roles:     parsed.Roles,
+		ExpiresAt: parsed.ExpiresAt.Time,
+	}, nil
+}
+
+// keySet caches the public keys of the JSON Web Key Set by their ids
+type keySet struct {
+	fetch   func(ctx context.Context) ([]byte, error)
+	refresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetched   time.Time
+	attempted time.Time
+}
+
+func newKeySet(fetch func(ctx context.Context) ([]byte, error), refresh time.Duration) *keySet {
+	return &keySet{fetch: fetch, refresh: refresh}
+}
+
+// key returns the key with the id, the set is fetched again when it is stale or misses the id.
+// The stale keys keep working while the service cannot be reached.
+func (s *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[kid]
+	if ok && time.Since(s.fetched) < s.refresh {
+		return key, nil
+	}
+
+	if s.attempted.IsZero() || time.Since(s.attempted) >= minRefetch {
+		s.attempted = time.Now()
+
+		data, err := s.fetch(ctx)
+		if err == nil {
+			var keys map[string]crypto.PublicKey
+			if keys, err = parseJWKS(data); err == nil {
+				s.keys, s.fetched = keys, s.attempted
+				key, ok = keys[kid]
+			}
+		}
+		if err != nil && !ok {
+			return nil, err
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS reads the signing keys of the set, the keys of unsupported types are skipped
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("malformed JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("malformed key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+// publicKey returns nil for the unsupported key types
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent is too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("wrong key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty number")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
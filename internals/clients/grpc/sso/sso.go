@@ -1 +1,222 @@
 package sso
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	ssov1 "github.com/nhassl3/pizzaland/api/generated/go/sso"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// retryPolicy retries the calls failed while the service was unreachable, %d is the number of attempts
+const retryPolicy = `{"methodConfig": [{
+	"name": [{"service": "github.nhassl3.sso.Auth.Auth"}],
+	"retryPolicy": {
+		"maxAttempts": %d,
+		"initialBackoff": "0.1s",
+		"maxBackoff": "1s",
+		"backoffMultiplier": 2,
+		"retryableStatusCodes": ["UNAVAILABLE"]
+	}
+}]}`
+
+// Client calls the SSO service and verifies the access tokens it issues
+type Client struct {
+	log     *slog.Logger
+	api     ssov1.AuthClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+	// keys is nil when the tokens are validated by the service
+	keys     *keySet
+	issuer   string
+	audience string
+	tokens   *cache[models.Identity]
+	users    *cache[models.Identity]
+}
+
+// New creates the client, the connection is established on the first call
+func New(log *slog.Logger, cfg config.SSO) (*Client, error) {
+	const op = "sso.New"
+
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if cfg.RetriesCount > 0 {
+		// gRPC caps the attempts at 5 whatever the policy says
+		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(retryPolicy, cfg.RetriesCount+1)))
+	}
+
+	conn, err := grpc.NewClient(cfg.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	client := &Client{
+		log:      log,
+		api:      ssov1.NewAuthClient(conn),
+		conn:     conn,
+		timeout:  cfg.Timeout,
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		tokens:   newCache[models.Identity](cfg.CacheTTL),
+		users:    newCache[models.Identity](cfg.CacheTTL),
+	}
+	if cfg.LocalVerify {
+		client.keys = newKeySet(client.fetchKeys, cfg.JWKSRefresh)
+	}
+
+	return client, nil
+}
+
+// Close closes the connection to the service
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Verify returns the owner of the access token. The token is checked against the keys of the service
+// when the local verification is on and by the service otherwise, either way the answer is cached.
+func (c *Client) Verify(ctx context.Context, token string) (models.Identity, error) {
+	const op = "sso.Client.Verify"
+
+	// the tokens are secrets, so only their digest is kept in memory
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+	if identity, ok := c.tokens.get(key); ok {
+		return identity, nil
+	}
+
+	var (
+		identity models.Identity
+		err      error
+	)
+	if c.keys != nil {
+		identity, err = c.verifyLocally(ctx, token)
+	} else {
+		identity, err = c.ValidateToken(ctx, token)
+	}
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.tokens.set(key, identity, identity.ExpiresAt)
+
+	return identity, nil
+}
+
+// ValidateToken asks the service for the owner of the access token
+func (c *Client) ValidateToken(ctx context.Context, token string) (models.Identity, error) {
+	const op = "sso.Client.ValidateToken"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.ValidateToken(ctx, &ssov1.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, mapError(err))
+	}
+
+	identity := toIdentity(resp.GetUser())
+	if resp.GetExpiresAt() != nil {
+		identity.ExpiresAt = resp.GetExpiresAt().AsTime()
+	}
+
+	return identity, nil
+}
+
+// GetUser returns the user with the roles, the answer is cached
+func (c *Client) GetUser(ctx context.Context, userID string) (models.Identity, error) {
+	const op = "sso.Client.GetUser"
+
+	if identity, ok := c.users.get(userID); ok {
+		return identity, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.GetUser(ctx, &ssov1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, mapError(err))
+	}
+
+	identity := toIdentity(resp.GetUser())
+	c.users.set(userID, identity, time.Time{})
+
+	return identity, nil
+}
+
+func (c *Client) fetchKeys(ctx context.Context) ([]byte, error) {
+	const op = "sso.Client.fetchKeys"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.GetJWKS(ctx, &ssov1.GetJWKSRequest{})
+	if err != nil {
+		c.log.Warn("failed to fetch the JWKS", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetJwks(), nil
+}
+
+func toIdentity(user *ssov1.User) models.Identity {
+	return models.Identity{
+		Subject: user.GetUserId(),
+		Email:   user.GetEmail(),
+		Roles:   user.GetRoles(),
+	}
+}
+
+// mapError turns the rejections of the service into the package errors, the failures are kept as they are
+func mapError(err error) error {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.InvalidArgument:
+		return ErrInvalidToken
+	case codes.NotFound:
+		return ErrUserNotFound
+	default:
+		return err
+	}
+}
+
+func transportCredentials(cfg config.SSO) (credentials.TransportCredentials, error) {
+	if cfg.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + cfg.CAFile)
+		}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
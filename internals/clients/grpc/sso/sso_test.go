@@ -0,0 +1,157 @@
+package sso_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso"
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso/ssotest"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var alice = ssotest.User{ID: "alice", Email: "alice@example.com", Roles: []string{"editor"}}
+
+func TestVerifyLocally(t *testing.T) {
+	server := newServer(t)
+	client := newClient(t, server.Config())
+	ctx := context.Background()
+
+	valid := issue(t, server, alice.ID, time.Hour)
+
+	identity, err := client.Verify(ctx, valid)
+	if err != nil {
+		t.Fatalf("valid token: %v", err)
+	}
+	if identity.Subject != alice.ID || identity.Email != alice.Email || len(identity.Roles) != 1 || identity.Roles[0] != "editor" {
+		t.Errorf("identity %+v, want %+v", identity, alice)
+	}
+	if identity.ExpiresAt.IsZero() {
+		t.Error("the expiry of the token is not kept")
+	}
+
+	expired := issue(t, server, alice.ID, -time.Hour)
+	if _, err := client.Verify(ctx, expired); !errors.Is(err, sso.ErrInvalidToken) {
+		t.Errorf("expired token: %v, want ErrInvalidToken", err)
+	}
+
+	// the claims of another token under the signature of the valid one
+	server.AddUser(ssotest.User{ID: "mallory", Roles: []string{"admin"}})
+	other := strings.Split(issue(t, server, "mallory", time.Hour), ".")
+	parts := strings.Split(valid, ".")
+	forged := strings.Join([]string{parts[0], other[1], parts[2]}, ".")
+	if _, err := client.Verify(ctx, forged); !errors.Is(err, sso.ErrInvalidToken) {
+		t.Errorf("token with a bad signature: %v, want ErrInvalidToken", err)
+	}
+
+	if calls := server.Calls("ValidateToken"); calls != 0 {
+		t.Errorf("the service validated %d tokens, want them verified locally", calls)
+	}
+	if calls := server.Calls("GetJWKS"); calls != 1 {
+		t.Errorf("the keys were fetched %d times, want 1", calls)
+	}
+}
+
+func TestVerifyCache(t *testing.T) {
+	server := newServer(t)
+
+	cfg := server.Config()
+	cfg.LocalVerify = false
+	client := newClient(t, cfg)
+
+	token := issue(t, server, alice.ID, time.Hour)
+	for range 3 {
+		if _, err := client.Verify(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls := server.Calls("ValidateToken"); calls != 1 {
+		t.Errorf("the service validated the token %d times, want the cached answer reused", calls)
+	}
+
+	// the rejections are not cached
+	expired := issue(t, server, alice.ID, -time.Hour)
+	for range 2 {
+		if _, err := client.Verify(context.Background(), expired); !errors.Is(err, sso.ErrInvalidToken) {
+			t.Fatalf("expired token: %v, want ErrInvalidToken", err)
+		}
+	}
+	if calls := server.Calls("ValidateToken"); calls != 3 {
+		t.Errorf("the service validated %d tokens, want 3", calls)
+	}
+}
+
+func TestRetriesUnavailable(t *testing.T) {
+	server := newServer(t)
+
+	cfg := server.Config()
+	cfg.LocalVerify = false
+	cfg.RetriesCount = 2
+	client := newClient(t, cfg)
+
+	server.FailWith(status.Error(codes.Unavailable, "down"))
+
+	_, err := client.Verify(context.Background(), issue(t, server, alice.ID, time.Hour))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("verify while the service is down: %v, want Unavailable", err)
+	}
+	if errors.Is(err, sso.ErrInvalidToken) {
+		t.Error("the failure is reported as a rejected token")
+	}
+	if calls := server.Calls("ValidateToken"); calls != cfg.RetriesCount+1 {
+		t.Errorf("%d attempts, want %d", calls, cfg.RetriesCount+1)
+	}
+
+	// the other failures are not retried
+	server.FailWith(status.Error(codes.Internal, "broken"))
+	if _, err := client.Verify(context.Background(), issue(t, server, alice.ID, 2*time.Hour)); err == nil {
+		t.Fatal("verify succeeded while the service is broken")
+	}
+	if calls := server.Calls("ValidateToken"); calls != cfg.RetriesCount+2 {
+		t.Errorf("%d attempts in total, want %d", calls, cfg.RetriesCount+2)
+	}
+}
+
+func newServer(t *testing.T) *ssotest.Server {
+	t.Helper()
+
+	server, err := ssotest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	server.AddUser(alice)
+
+	return server
+}
+
+func newClient(t *testing.T, cfg config.SSO) *sso.Client {
+	t.Helper()
+
+	client, err := sso.New(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func issue(t *testing.T, server *ssotest.Server, userID string, ttl time.Duration) string {
+	t.Helper()
+
+	token, err := server.Issue(userID, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
@@ -0,0 +1,249 @@
+// Package ssotest runs a fake SSO service for the tests of the authenticated calls
+package ssotest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	ssov1 "github.com/nhassl3/pizzaland/api/generated/go/sso"
+	"github.com/nhassl3/pizzaland/internals/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const Issuer = "ssotest"
+
+type User struct {
+	ID    string
+	Email string
+	Roles []string
+}
+
+// Server is the SSO service on a random loopback port, signing its tokens with an ES256 key
+type Server struct {
+	ssov1.UnimplementedAuthServer
+
+	// Addr is the host:port the service listens on
+	Addr string
+
+	server *grpc.Server
+
+	mu    sync.Mutex
+	key   *ecdsa.PrivateKey
+	kid   string
+	users map[string]User
+	calls map[string]int
+	err   error
+}
+
+// NewServer starts the service, it is stopped with Close
+func NewServer() (*Server, error) {
+	const op = "ssotest.NewServer"
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s := &Server{
+		Addr:  lis.Addr().String(),
+		users: make(map[string]User),
+		calls: make(map[string]int),
+	}
+	if err := s.RotateKey(); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+	ssov1.RegisterAuthServer(s.server, s)
+
+	go func() {
+		_ = s.server.Serve(lis)
+	}()
+
+	return s, nil
+}
+
+func (s *Server) Close() {
+	s.server.Stop()
+}
+
+// Config points the client to the service
+func (s *Server) Config() config.SSO {
+	return config.SSO{
+		Enabled:     true,
+		Address:     s.Addr,
+		Timeout:     time.Second,
+		Insecure:    true,
+		LocalVerify: true,
+		JWKSRefresh: 10 * time.Minute,
+		Issuer:      Issuer,
+		CacheTTL:    time.Minute,
+	}
+}
+
+func (s *Server) AddUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[user.ID] = user
+}
+
+// Issue signs the access token of the added user living for ttl
+func (s *Server) Issue(userID string, ttl time.Duration) (string, error) {
+	const op = "ssotest.Server.Issue"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return "", fmt.Errorf("%s: unknown user %q", op, userID)
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss":   Issuer,
+		"sub":   user.ID,
+		"email": user.Email,
+		"roles": user.Roles,
+		"iat":   now.Unix(),
+		"exp":   now.Add(ttl).Unix(),
+	})
+	token.Header["kid"] = s.kid
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return signed, nil
+}
+
+// RotateKey replaces the signing key, the tokens signed with the previous one stop being valid
+func (s *Server) RotateKey() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.key, s.kid = key, hex.EncodeToString(kid)
+
+	return nil
+}
+
+// FailWith makes every call fail with err until it is called with nil
+func (s *Server) FailWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// Calls returns how many times the method, named like "ValidateToken", was called
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+func (s *Server) ValidateToken(_ context.Context, req *ssov1.ValidateTokenRequest) (*ssov1.ValidateTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claims := jwt.RegisteredClaims{}
+	if _, err := jwt.ParseWithClaims(req.GetToken(), &claims, func(*jwt.Token) (any, error) {
+		return &s.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}), jwt.WithExpirationRequired()); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	user, ok := s.users[claims.Subject]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unknown user")
+	}
+
+	return &ssov1.ValidateTokenResponse{
+		User:      toUser(user),
+		ExpiresAt: timestamppb.New(claims.ExpiresAt.Time),
+	}, nil
+}
+
+func (s *Server) GetUser(_ context.Context, req *ssov1.GetUserRequest) (*ssov1.GetUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return &ssov1.GetUserResponse{User: toUser(user)}, nil
+}
+
+func (s *Server) GetJWKS(context.Context, *ssov1.GetJWKSRequest) (*ssov1.GetJWKSResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encode := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"kid": s.kid,
+			"use": "sig",
+			"alg": jwt.SigningMethodES256.Alg(),
+			"crv": "P-256",
+			"x":   encode(s.key.PublicKey.X.FillBytes(make([]byte, 32))),
+			"y":   encode(s.key.PublicKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &ssov1.GetJWKSResponse{Jwks: jwks}, nil
+}
+
+// intercept counts the calls and fails them when FailWith is set
+func (s *Server) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	s.mu.Lock()
+	s.calls[path.Base(info.FullMethod)]++
+	err := s.err
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func toUser(user User) *ssov1.User {
+	return &ssov1.User{UserId: user.ID, Email: user.Email, Roles: user.Roles}
+}
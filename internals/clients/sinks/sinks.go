@@ -0,0 +1,147 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/nhassl3/pizzaland/internals/config"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+// ContentType is the media type of the events in the CloudEvents structured JSON format
+const ContentType = "application/cloudevents+json"
+
+// Sink delivers the events, Close releases what it holds
+type Sink interface {
+	Deliver(ctx context.Context, event models.Event) error
+	Close() error
+}
+
+// New creates the sink of the type configured
+func New(cfg config.OutboxSink) (Sink, error) {
+	const op = "sinks.New"
+
+	switch cfg.Type {
+	case "stdout":
+		return NewWriter(os.Stdout), nil
+	case "file":
+		sink, err := NewFile(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return sink, nil
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("%s: the webhook sink needs the url", op)
+		}
+
+		return NewWebhook(cfg.URL, &http.Client{Timeout: cfg.Timeout}), nil
+	default:
+		return nil, fmt.Errorf("%s: unknown sink type %q", op, cfg.Type)
+	}
+}
+
+// Writer writes every event as a JSON line, it is meant for the tests and the local debugging
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (s *Writer) Deliver(_ context.Context, event models.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+
+	return err
+}
+
+func (s *Writer) Close() error {
+	return nil
+}
+
+// File appends every event as a JSON line to the file and syncs it before the event counts as delivered
+type File struct {
+	Writer
+	file *os.File
+}
+
+func NewFile(path string) (*File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{Writer: Writer{w: file}, file: file}, nil
+}
+
+func (s *File) Deliver(ctx context.Context, event models.Event) error {
+	if err := s.Writer.Deliver(ctx, event); err != nil {
+		return err
+	}
+
+	return s.file.Sync()
+}
+
+func (s *File) Close() error {
+	return s.file.Close()
+}
+
+// Webhook posts every event to the URL, any status but 2xx fails the delivery
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, client *http.Client) *Webhook {
+	return &Webhook{url: url, client: client}
+}
+
+func (s *Webhook) Deliver(ctx context.Context, event models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// drained, so the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *Webhook) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
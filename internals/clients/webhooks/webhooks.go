@@ -0,0 +1,125 @@
+// Package webhooks sends the signed deliveries to the webhooks of the partners
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ContentType is the media type of the events in the CloudEvents structured JSON format
+	ContentType = "application/cloudevents+json"
+
+	// TimestampHeader carries the unix time of the attempt in seconds, it is signed with the body
+	TimestampHeader = "X-PizzaLand-Timestamp"
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret of the webhook
+	SignatureHeader = "X-PizzaLand-Signature"
+	// DeliveryHeader carries the id of the delivery, it is the same for all its attempts
+	DeliveryHeader = "X-PizzaLand-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("stale webhook timestamp")
+)
+
+// StatusError is the failed delivery the webhook responded to
+type StatusError struct {
+	Code   int
+	Status string
+}
+
+func (e *StatusError) Error() string {
+	return "webhook responded with " + e.Status
+}
+
+// Client posts the deliveries, any status but 2xx fails them
+type Client struct {
+	client *http.Client
+}
+
+func New(client *http.Client) *Client {
+	return &Client{client: client}
+}
+
+// Send posts the payload signed with the secret, code is the status of the response and zero when there was none
+func (c *Client) Send(ctx context.Context, url, secret string, deliveryId uint64, payload []byte) (code int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", ContentType)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(deliveryId, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, payload))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drained, so the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, &StatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (c *Client) Close() {
+	c.client.CloseIdleConnections()
+}
+
+// Sign returns the value of SignatureHeader for the body sent at timestamp
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the received delivery the way the receivers should.
+// The deliveries signed more than tolerance away from now are refused, so the captured ones cannot be replayed.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	const op = "webhooks.Verify"
+
+	signature, unix := header.Get(SignatureHeader), header.Get(TimestampHeader)
+	if signature == "" || unix == "" {
+		return fmt.Errorf("%s: %w", op, ErrMissingSignature)
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s: %w: malformed timestamp", op, ErrInvalidSignature)
+	}
+	timestamp := time.Unix(seconds, 0)
+	if timestamp.Before(now.Add(-tolerance)) || timestamp.After(now.Add(tolerance)) {
+		return fmt.Errorf("%s: %w", op, ErrStaleTimestamp)
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return fmt.Errorf("%s: %w", op, ErrInvalidSignature)
+	}
+
+	return nil
+}
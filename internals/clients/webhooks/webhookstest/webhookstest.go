@@ -0,0 +1,109 @@
+// Package webhookstest runs a stand-in receiver of the webhook deliveries for the tests
+package webhookstest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/clients/webhooks"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+// Tolerance is how far the timestamps of the accepted deliveries may be from the clock of the receiver
+const Tolerance = 5 * time.Minute
+
+// Delivery is a received request which passed the signature check
+type Delivery struct {
+	ID     string
+	Header http.Header
+	Event  models.Event
+}
+
+// Receiver is the webhook of a partner on a random loopback port, it verifies the signatures with Secret.
+// The requests failing the check are answered with 401 and counted in Rejected.
+type Receiver struct {
+	// URL is where the webhook is registered
+	URL    string
+	Secret string
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	deliveries []Delivery
+	rejected   int
+	failures   []int
+}
+
+// NewReceiver starts the receiver, it is stopped with Close
+func NewReceiver(secret string) *Receiver {
+	r := &Receiver{Secret: secret}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	r.URL = r.server.URL
+
+	return r
+}
+
+func (r *Receiver) Close() {
+	r.server.Close()
+}
+
+// FailWith answers the next requests with the statuses in order before accepting again, e.g. FailWith(500, 503)
+func (r *Receiver) FailWith(codes ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, codes...)
+}
+
+// Deliveries returns the accepted deliveries in the order they were received, the repeated ones included
+func (r *Receiver) Deliveries() []Delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Delivery(nil), r.deliveries...)
+}
+
+// Rejected returns how many requests failed the signature check
+func (r *Receiver) Rejected() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rejected
+}
+
+func (r *Receiver) serve(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := webhooks.Verify(r.Secret, req.Header, body, Tolerance, time.Now()); err != nil {
+		r.rejected++
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if len(r.failures) != 0 {
+		code := r.failures[0]
+		r.failures = r.failures[1:]
+		w.WriteHeader(code)
+		return
+	}
+
+	var event models.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.deliveries = append(r.deliveries, Delivery{ID: req.Header.Get(webhooks.DeliveryHeader), Header: req.Header, Event: event})
+	w.WriteHeader(http.StatusNoContent)
+}
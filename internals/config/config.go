@@ -18,12 +18,226 @@ func init() {
 type Config struct {
 	EnvLevel    int    `yaml:"env_level" env-default:"1"`
 	StoragePath string `yaml:"storage_path" env-required:"true"`
-	GRPC        GRPC   `yaml:"grpc"`
+	// ShutdownTimeout bounds the wait for the in-flight calls, the calls still running after it are cancelled
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"15s"`
+	GRPC            GRPC          `yaml:"grpc"`
+	Gateway         Gateway       `yaml:"gateway"`
+	Admin           Admin         `yaml:"admin"`
+	Tracing         Tracing       `yaml:"tracing"`
+	Purge           Purge         `yaml:"purge"`
+	Idempotency     Idempotency   `yaml:"idempotency"`
+	Clients         Clients       `yaml:"clients"`
+	Outbox          Outbox        `yaml:"outbox"`
+	Watch           Watch         `yaml:"watch"`
+	Webhooks        Webhooks      `yaml:"webhooks"`
 }
 
 type GRPC struct {
+	// Host is the address to listen on, 0.0.0.0 makes the server reachable from other hosts and containers
+	Host    string        `yaml:"host" env-default:"127.0.0.1"`
 	Port    int           `yaml:"port" env-default:"44044"`
+	TLS     TLS           `yaml:"tls"`
 	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// MethodTimeouts override Timeout for single methods, keyed by the full or the bare method name.
+	// Timeout does not apply to the server streams, they are bound only by their entry here.
+	MethodTimeouts map[string]time.Duration `yaml:"method_timeouts"`
+	// Interceptors lists the server interceptors in the order they wrap the call, the first one is the outermost
+	Interceptors []string `yaml:"interceptors" env-default:"request_id,tracing,logging,recovery"`
+	// HealthInterval is how often the storage is pinged to update the health status
+	HealthInterval time.Duration `yaml:"health_interval" env-default:"5s"`
+	// Reflection registers the server reflection service for tools like grpcurl
+	Reflection bool      `yaml:"reflection" env-default:"false"`
+	Web        Web       `yaml:"web"`
+	RateLimit  RateLimit `yaml:"rate_limit"`
+	RBAC       RBAC      `yaml:"rbac"`
+	APIKeys    APIKeys   `yaml:"api_keys"`
+}
+
+// APIKeys authenticates the machine clients by the "x-api-key" metadata, the keys are managed with the *APIKey methods
+type APIKeys struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+}
+
+// RBAC lets the authenticated callers call only the PizzaLand methods their roles allow
+type RBAC struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// PolicyFile maps the roles to the methods and categories, it is reloaded when it changes on disk
+	PolicyFile string `yaml:"policy_file" env-default:"./config/rbac_policy.yaml"`
+}
+
+// RateLimit gives every client a token bucket per method and caps the writes running at once
+type RateLimit struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Rate is the calls per second a client may make to a method, Burst is how many it may make at once
+	Rate  float64 `yaml:"rate" env-default:"20"`
+	Burst int     `yaml:"burst" env-default:"40"`
+	// Methods override Rate and Burst for single methods, keyed by the full or the bare method name
+	Methods map[string]Budget `yaml:"methods"`
+	// MaxInFlightWrites caps the writes running at once over all clients, zero leaves them uncapped
+	MaxInFlightWrites int `yaml:"max_in_flight_writes" env-default:"4"`
+	// WriteRetryAfter is suggested to the clients whose writes are rejected by the cap
+	WriteRetryAfter time.Duration `yaml:"write_retry_after" env-default:"1s"`
+	// TrustedProxies lists the addresses or the CIDR ranges of the proxies, like the gateway,
+	// whose callers are told apart by the x-forwarded-for metadata, it is ignored from any other peer.
+	// The idempotency keys of the anonymous callers and the invalid API key limits are scoped the same way,
+	// even with the limits off.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+type Budget struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Web serves gRPC-Web and Connect calls on the gRPC port next to the native gRPC ones.
+// Without TLS the port speaks HTTP/1.1 and HTTP/2 over cleartext (h2c).
+type Web struct {
+	Enabled           bool          `yaml:"enabled" env-default:"false"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env-default:"5s"`
+	CORS              CORS          `yaml:"cors"`
+}
+
+// CORS configures the cross-origin requests of the browser clients, no origin is allowed by default
+type CORS struct {
+	// AllowedOrigins lists the origins of the browser clients, "*" allows any origin
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// AllowedHeaders are allowed on top of the headers the gRPC-Web and Connect clients send
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials" env-default:"false"`
+	MaxAge           time.Duration `yaml:"max_age" env-default:"10m"`
+}
+
+// TLS enables TLS when the certificate and key files are set, the files are reloaded when they change on disk
+type TLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile makes the server require client certificates signed by one of its CAs
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+// Gateway configures the REST/JSON gateway in front of the gRPC server
+type Gateway struct {
+	Enabled           bool          `yaml:"enabled" env-default:"false"`
+	Host              string        `yaml:"host" env-default:"127.0.0.1"`
+	Port              int           `yaml:"port" env-default:"8080"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env-default:"5s"`
+	// CAFile verifies the gRPC server when it serves TLS, the system roots are used when it is empty
+	CAFile string `yaml:"ca_file"`
+	// CertFile and KeyFile are the client certificate presented to the gRPC server requiring mutual TLS
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// Admin configures the HTTP server of the operational endpoints, such as the Prometheus /metrics
+type Admin struct {
+	Enabled           bool          `yaml:"enabled" env-default:"false"`
+	Host              string        `yaml:"host" env-default:"127.0.0.1"`
+	Port              int           `yaml:"port" env-default:"9090"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env-default:"5s"`
+}
+
+// Tracing configures the export of the OpenTelemetry traces
+type Tracing struct {
+	// Exporter is one of "otlp", "stdout" and "file", the spans are not recorded when it is empty
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector
+	Endpoint string `yaml:"endpoint" env-default:"localhost:4317"`
+	Insecure bool   `yaml:"insecure" env-default:"false"`
+	// File receives the spans of the file exporter as JSON
+	File string `yaml:"file" env-default:"./traces.json"`
+	// SampleRatio is the share of the new traces to record, the continued ones follow the decision of the caller
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+	ServiceName string  `yaml:"service_name" env-default:"pizzaland"`
+}
+
+// Purge configures the job which hard-deletes soft deleted pizzas and categories
+type Purge struct {
+	Enabled       bool          `yaml:"enabled" env-default:"false"`
+	Interval      time.Duration `yaml:"interval" env-default:"24h"`
+	RetentionDays int           `yaml:"retention_days" env-default:"30"`
+}
+
+// Outbox delivers the change events written together with every catalog change to the sink
+type Outbox struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Interval is how often the queue is checked when it was found empty
+	Interval  time.Duration `yaml:"interval" env-default:"1s"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+	// MaxAttempts is how many times an event is tried before it goes to the dead_letters table
+	MaxAttempts int `yaml:"max_attempts" env-default:"10"`
+	// MinBackoff is the wait after the first failure, it doubles with every next one up to MaxBackoff
+	MinBackoff time.Duration `yaml:"min_backoff" env-default:"1s"`
+	MaxBackoff time.Duration `yaml:"max_backoff" env-default:"10m"`
+	Sink       OutboxSink    `yaml:"sink"`
+}
+
+// OutboxSink is where the change events are delivered
+type OutboxSink struct {
+	// Type is one of "webhook", "file" and "stdout"
+	Type string `yaml:"type" env-default:"stdout"`
+	// URL receives the events of the webhook sink as POST requests
+	URL     string        `yaml:"url"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// File receives the events of the file sink as JSON lines
+	File string `yaml:"file" env-default:"./events.jsonl"`
+}
+
+// Watch configures the WatchMenu streams
+type Watch struct {
+	// History is how many latest changes are kept for the watchers resuming after a disconnect
+	History int `yaml:"history" env-default:"1024"`
+	// SubscriberBuffer is how many changes a watcher may lag behind before its stream is ended
+	SubscriberBuffer int `yaml:"subscriber_buffer" env-default:"256"`
+}
+
+// Webhooks calls the webhooks of the partners with the change events of their types
+type Webhooks struct {
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Interval is how often the due deliveries are checked when none were found
+	Interval  time.Duration `yaml:"interval" env-default:"1s"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+	// Timeout limits every attempt including the response
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	// MaxAttempts is how many times a delivery is tried before it fails
+	MaxAttempts int `yaml:"max_attempts" env-default:"10"`
+	// DisableAfter is how many failed attempts in a row disable the webhook until it is enabled again
+	DisableAfter int `yaml:"disable_after" env-default:"15"`
+	// MinBackoff is the wait after the first failure of a webhook, it doubles with every next one up to MaxBackoff
+	MinBackoff time.Duration `yaml:"min_backoff" env-default:"1s"`
+	MaxBackoff time.Duration `yaml:"max_backoff" env-default:"10m"`
+}
+
+// Idempotency configures how long the idempotency keys of Save requests are remembered
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+}
+
+// Clients configures the clients of the other services
+type Clients struct {
+	SSO SSO `yaml:"sso"`
+}
+
+// SSO authenticates the callers of the gRPC server by the access tokens issued by the SSO service.
+// When it is enabled every PizzaLand call needs the "authorization: Bearer <token>" metadata.
+type SSO struct {
+	Enabled bool   `yaml:"enabled" env-default:"false"`
+	Address string `yaml:"address" env-default:"localhost:44043"`
+	// Timeout bounds the whole call to the SSO service, the retries and the backoff between them included
+	Timeout time.Duration `yaml:"timeout" env-default:"3s"`
+	// RetriesCount is the number of retries of the calls failed while the service was unreachable
+	RetriesCount int `yaml:"retries_count" env-default:"3"`
+	// Insecure connects without TLS, CAFile verifies the service otherwise and the system roots are used when it is empty
+	Insecure bool   `yaml:"insecure" env-default:"false"`
+	CAFile   string `yaml:"ca_file"`
+	// LocalVerify checks the token signatures with the keys of the JWKS of the service instead of asking it
+	LocalVerify bool `yaml:"local_verify" env-default:"true"`
+	// JWKSRefresh is how often the keys are fetched again, an unknown key id fetches them earlier
+	JWKSRefresh time.Duration `yaml:"jwks_refresh" env-default:"10m"`
+	// Issuer and Audience are required in the verified tokens when they are set
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// CacheTTL is how long the answers of the service are reused, never longer than the token lives
+	CacheTTL time.Duration `yaml:"cache_ttl" env-default:"1m"`
 }
 
 func MustLoadByString(path string) *Config {
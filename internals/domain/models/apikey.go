@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// APIKey is the credential of a machine client, only the hash of its secret is stored
+type APIKey struct {
+	ID uint64
+	// Prefix is the public part of the secret the key is looked up by
+	Prefix     string
+	SecretHash string
+	Label      string
+	// Scopes are the roles the key is granted
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt, LastUsedAt and RevokedAt are zero when not set
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AuditEvent is a single change of the catalog
+type AuditEvent struct {
+	ID         uint64
+	OccurredAt time.Time
+	// Actor is the subject of the caller, "anonymous" when the calls are not authenticated
+	Actor     string
+	RequestId string
+	// Operation is one of save, update, remove and restore
+	Operation string
+	// Entity is pizza or category
+	Entity   string
+	EntityId uint64
+	// Before and After are the JSON of the entity, empty when it did not exist or was in the trash
+	Before string
+	After  string
+}
+
+// AuditFilter selects the audit events, zero fields match everything
+type AuditFilter struct {
+	Entity string
+	Actor  string
+	// From is inclusive, To is exclusive
+	From time.Time
+	To   time.Time
+}
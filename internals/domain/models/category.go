@@ -5,3 +5,20 @@ type Category struct {
 	Name        string
 	Description string
 }
+
+// CategoryUpdate holds the category fields to change, nil fields are kept as is
+type CategoryUpdate struct {
+	Name        *string
+	Description *string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u CategoryUpdate) IsEmpty() bool {
+	return u.Name == nil && u.Description == nil
+}
+
+// CategoryPizzas is the number of live pizzas in the live category
+type CategoryPizzas struct {
+	Name   string
+	Pizzas int64
+}
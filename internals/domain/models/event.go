@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventSpecVersion is the CloudEvents version of the events
+const EventSpecVersion = "1.0"
+
+// Event is a change notification, it marshals into the CloudEvents structured JSON format
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// OutboxEvent is an event waiting for its delivery
+type OutboxEvent struct {
+	// Seq orders the events the way they were written
+	Seq           uint64
+	Event         Event
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// IdempotencyKey is the stored outcome of a request made with an idempotency key
+type IdempotencyKey struct {
+	Scope       string
+	Key         string
+	RequestHash []byte
+	Response    []byte
+	ExpiresAt   time.Time
+}
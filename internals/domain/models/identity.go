@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Identity is the authenticated caller
+type Identity struct {
+	Subject string
+	Email   string
+	Roles   []string
+	// ExpiresAt is when the credentials of the caller expire, zero when they do not
+	ExpiresAt time.Time
+}
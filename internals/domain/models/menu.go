@@ -0,0 +1,51 @@
+package models
+
+// the kinds of the menu rows
+const (
+	MenuRowCategory = "category"
+	MenuRowPizza    = "pizza"
+)
+
+// MenuRow is a category or a pizza read from the imported menu file
+type MenuRow struct {
+	// Row is the number of the entry in the file starting from 1, Line is the line it starts at
+	Row  int
+	Line int
+	// Kind is category or pizza
+	Kind string
+	// Category is the name of the category of the pizza, it may be imported by the same file
+	Category    string
+	Name        string
+	Description string
+	TypeDough   int32
+	Price       float32
+	Diameter    uint32
+}
+
+// ImportError is the problem of a single menu row, Field is empty when it is not about one field
+type ImportError struct {
+	Row     int
+	Line    int
+	Field   string
+	Message string
+}
+
+// ImportOptions control how the menu is imported
+type ImportOptions struct {
+	// DryRun checks every row and counts the changes but writes nothing
+	DryRun bool
+	// Upsert updates the categories and pizzas found by name instead of failing on them
+	Upsert bool
+}
+
+// ImportSummary is the outcome of the menu import, nothing is written when there are errors
+type ImportSummary struct {
+	Applied           bool
+	CategoriesCreated int
+	CategoriesUpdated int
+	PizzasCreated     int
+	PizzasUpdated     int
+	// Unchanged counts the upserted rows equal to what is stored
+	Unchanged int
+	Errors    []ImportError
+}
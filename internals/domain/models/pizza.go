@@ -14,3 +14,23 @@ type TypeDough struct {
 	ID   uint32
 	Name string
 }
+
+// PizzaUpdate holds the pizza fields to change, nil fields are kept as is
+type PizzaUpdate struct {
+	CategoryId  *uint32
+	Name        *string
+	Description *string
+	TypeDough   *int32
+	Price       *float32
+	Diameter    *uint32
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u PizzaUpdate) IsEmpty() bool {
+	return u.CategoryId == nil &&
+		u.Name == nil &&
+		u.Description == nil &&
+		u.TypeDough == nil &&
+		u.Price == nil &&
+		u.Diameter == nil
+}
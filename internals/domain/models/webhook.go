@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// the statuses of the webhook deliveries
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook is the subscription of a partner to the change events, it is called with the events of its types
+type Webhook struct {
+	ID  uint64
+	URL string
+	// EventTypes are the types of the events sent, e.g. "pizzaland.pizza.updated"
+	EventTypes []string
+	// Secret signs the deliveries, it is stored as is since the signatures need it
+	Secret  string
+	Enabled bool
+	// ConsecutiveFailures counts the failed attempts since the last successful one
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	// DisabledAt is zero while the webhook is enabled
+	DisabledAt time.Time
+}
+
+// WebhookUpdate changes the set fields of the webhook, the nil and empty ones are kept
+type WebhookUpdate struct {
+	URL        *string
+	EventTypes []string
+	Secret     *string
+	Enabled    *bool
+}
+
+// WebhookDelivery is a single event sent to a webhook and the outcome of its attempts
+type WebhookDelivery struct {
+	ID        uint64
+	WebhookId uint64
+	EventId   string
+	EventType string
+	// Payload is the event in the CloudEvents structured JSON format, sent as the request body
+	Payload string
+	// Status is one of pending, delivered and failed
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	// ResponseCode is the HTTP status of the last attempt, zero when no response was received
+	ResponseCode int
+	LastError    string
+	CreatedAt    time.Time
+	// CompletedAt is zero while the delivery is pending
+	CompletedAt time.Time
+}
@@ -0,0 +1,317 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// keyPrefix starts every key, so the leaked ones are easy to find by the secret scanners
+	keyPrefix = "pl_"
+	// prefixLen and secretLen are the random bytes of the public and the secret part of the key
+	prefixLen = 6
+	secretLen = 32
+
+	// the argon2id parameters recommended by OWASP
+	argonTime    = 2
+	argonMemory  = 19 * 1024
+	argonThreads = 1
+	argonKeyLen  = 32
+	saltLen      = 16
+
+	// cacheTTL is how long a verified key is trusted without the storage, it also limits the last use updates
+	cacheTTL = time.Minute
+)
+
+var (
+	ErrInvalidKey  = errors.New("invalid api key")
+	ErrKeyNotFound = errors.New("api key not found")
+)
+
+type Store interface {
+	SaveAPIKey(ctx context.Context, key models.APIKey) (id uint64, err error)
+	APIKeyByPrefix(ctx context.Context, prefix string) (key models.APIKey, err error)
+	ListAPIKeys(ctx context.Context, offset uint32, limit uint32) (keys []models.APIKey, err error)
+	RevokeAPIKey(ctx context.Context, id uint64, at time.Time) error
+	TouchAPIKey(ctx context.Context, id uint64, at time.Time) error
+}
+
+// APIKeys issues and verifies the API keys of the machine clients
+type APIKeys struct {
+	log   *slog.Logger
+	store Store
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]verifiedKey
+}
+
+type verifiedKey struct {
+	identity  models.Identity
+	keyId     uint64
+	expiresAt time.Time
+}
+
+func New(log *slog.Logger, store Store) *APIKeys {
+	return &APIKeys{
+		log:      log,
+		store:    store,
+		verified: make(map[[sha256.Size]byte]verifiedKey),
+	}
+}
+
+// Create stores the new key and returns it together with the secret, which is not stored anywhere
+func (a *APIKeys) Create(ctx context.Context, label string, scopes []string, expiresAt time.Time) (models.APIKey, string, error) {
+	const op = "apikeys.Create"
+
+	log := logger.FromContext(ctx, a.log).With(slog.String("op", op))
+
+	prefix, err := randomString(prefixLen, hex.EncodeToString)
+	if err != nil {
+		return models.APIKey{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	secret, err := randomString(secretLen, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return models.APIKey{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	secret = keyPrefix + prefix + "_" + secret
+
+	hash, err := hashSecret(secret)
+	if err != nil {
+		return models.APIKey{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := models.APIKey{
+		Prefix:     prefix,
+		SecretHash: hash,
+		Label:      label,
+		Scopes:     scopes,
+		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  expiresAt,
+	}
+
+	if key.ID, err = a.store.SaveAPIKey(ctx, key); err != nil {
+		log.Error("failed to save api key", slog.String("error", err.Error()))
+		return models.APIKey{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("api key created", slog.Uint64("key_id", key.ID), slog.String("prefix", prefix), slog.String("label", label))
+
+	return key, secret, nil
+}
+
+func (a *APIKeys) List(ctx context.Context, offset, limit uint32) ([]models.APIKey, error) {
+	const op = "apikeys.List"
+
+	keys, err := a.store.ListAPIKeys(ctx, offset, limit)
+	if err != nil {
+		logger.FromContext(ctx, a.log).Error("failed to list api keys", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// Revoke makes the key invalid at once, the cached verifications of it included
+func (a *APIKeys) Revoke(ctx context.Context, id uint64) error {
+	const op = "apikeys.Revoke"
+
+	log := logger.FromContext(ctx, a.log).With(slog.String("op", op), slog.Uint64("key_id", id))
+
+	if err := a.store.RevokeAPIKey(ctx, id, time.Now()); err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrKeyNotFound)
+		}
+
+		log.Error("failed to revoke api key", slog.String("error", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	a.mu.Lock()
+	for digest, verified := range a.verified {
+		if verified.keyId == id {
+			delete(a.verified, digest)
+		}
+	}
+	a.mu.Unlock()
+
+	log.Info("api key revoked")
+
+	return nil
+}
+
+// Verify returns the identity of the key, the subject is "apikey:<id>" and the roles are the scopes of the key.
+// The unknown, revoked and expired keys fail with ErrInvalidKey.
+func (a *APIKeys) Verify(ctx context.Context, secret string) (models.Identity, error) {
+	const op = "apikeys.Verify"
+
+	if identity, ok := a.Cached(secret); ok {
+		return identity, nil
+	}
+
+	digest := sha256.Sum256([]byte(secret))
+	now := time.Now()
+
+	prefix, ok := parseKey(secret)
+	if !ok {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+	}
+
+	key, err := a.store.APIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			return models.Identity{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+		}
+
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case !key.RevokedAt.IsZero():
+		return models.Identity{}, fmt.Errorf("%s: %w: revoked", op, ErrInvalidKey)
+	case !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt):
+		return models.Identity{}, fmt.Errorf("%s: %w: expired", op, ErrInvalidKey)
+	}
+
+	match, err := verifySecret(secret, key.SecretHash)
+	if err != nil {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !match {
+		return models.Identity{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+	}
+
+	// the last use is recorded once per cacheTTL, the cached verifications do not reach the storage
+	if err := a.store.TouchAPIKey(ctx, key.ID, now); err != nil {
+		logger.FromContext(ctx, a.log).Warn("failed to record api key use", slog.String("op", op), slog.String("error", err.Error()))
+	}
+
+	identity := models.Identity{
+		Subject:   "apikey:" + strconv.FormatUint(key.ID, 10),
+		Roles:     key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+	}
+
+	expiresAt := now.Add(cacheTTL)
+	if !key.ExpiresAt.IsZero() && key.ExpiresAt.Before(expiresAt) {
+		expiresAt = key.ExpiresAt
+	}
+
+	a.mu.Lock()
+	a.sweep(now)
+	a.verified[digest] = verifiedKey{identity: identity, keyId: key.ID, expiresAt: expiresAt}
+	a.mu.Unlock()
+
+	return identity, nil
+}
+
+// Cached returns the identity of the key verified within the last cacheTTL without hashing the secret
+func (a *APIKeys) Cached(secret string) (models.Identity, bool) {
+	digest := sha256.Sum256([]byte(secret))
+
+	a.mu.Lock()
+	verified, ok := a.verified[digest]
+	a.mu.Unlock()
+	if !ok || !time.Now().Before(verified.expiresAt) {
+		return models.Identity{}, false
+	}
+
+	return verified.identity, true
+}
+
+// sweep drops the expired verifications, the caller holds the lock
+func (a *APIKeys) sweep(now time.Time) {
+	for digest, verified := range a.verified {
+		if !now.Before(verified.expiresAt) {
+			delete(a.verified, digest)
+		}
+	}
+}
+
+// parseKey returns the prefix of the key formatted as "pl_<prefix>_<secret>"
+func parseKey(secret string) (string, bool) {
+	rest, ok := strings.CutPrefix(secret, keyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	prefix, _, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(prefixLen) {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+// hashSecret returns the argon2id hash of the secret in the PHC string format
+func hashSecret(secret string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	hash := argon2.IDKey([]byte(secret), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argonMemory, argonTime, argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash),
+	), nil
+}
+
+// verifySecret compares the secret with the hash using the parameters stored in it
+func verifySecret(secret, encoded string) (bool, error) {
+	var (
+		version            int
+		memory, iterations uint32
+		threads            uint8
+	)
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, errors.New("unsupported secret hash")
+	}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, errors.New("unsupported argon2 version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, fmt.Errorf("malformed secret hash: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("malformed secret hash: %w", err)
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("malformed secret hash: %w", err)
+	}
+
+	computed := argon2.IDKey([]byte(secret), salt, iterations, memory, threads, uint32(len(hash)))
+
+	return subtle.ConstantTimeCompare(computed, hash) == 1, nil
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encode(b), nil
+}
@@ -0,0 +1,139 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrKeyInProgress = errors.New("request with the same idempotency key is in progress")
+)
+
+type Store interface {
+	IdempotencyKey(ctx context.Context, scope, key string) (record models.IdempotencyKey, err error)
+	SaveIdempotencyKey(ctx context.Context, record models.IdempotencyKey) error
+}
+
+// Transactor binds the storage calls made with the context passed to fn to a single transaction
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Idempotency struct {
+	log   *slog.Logger
+	store Store
+	tx    Transactor
+	ttl   time.Duration
+}
+
+func New(
+	log *slog.Logger,
+	store Store,
+	tx Transactor,
+	ttl time.Duration,
+) *Idempotency {
+	return &Idempotency{
+		log:   log,
+		store: store,
+		tx:    tx,
+		ttl:   ttl,
+	}
+}
+
+// Do runs fn at most once per scope and key until the key expires.
+// fn fills response, which is stored together with the request fingerprint in the same transaction.
+// A replay of the request fills response with the stored one instead of running fn.
+// Failed calls are not remembered, so the client is free to retry them with the same key.
+// Empty key runs fn without any bookkeeping.
+func (i *Idempotency) Do(
+	ctx context.Context,
+	scope, key string,
+	request, response proto.Message,
+	fn func(ctx context.Context) error,
+) error {
+	const op = "idempotency.Do"
+
+	if key == "" {
+		return fn(ctx)
+	}
+
+	log := logger.FromContext(ctx, i.log).With(slog.String("op", op), slog.String("scope", scope), slog.String("key", key))
+
+	hash, err := fingerprint(request)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = i.tx.InTx(ctx, func(ctx context.Context) error {
+		record, err := i.store.IdempotencyKey(ctx, scope, key)
+		switch {
+		case err == nil:
+			if !bytes.Equal(record.RequestHash, hash) {
+				return ErrKeyReused
+			}
+
+			log.Info("replaying stored response")
+
+			return proto.Unmarshal(record.Response, response)
+		case !errors.Is(err, storage.ErrIdempotencyKeyNotFound):
+			return err
+		}
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		payload, err := proto.Marshal(response)
+		if err != nil {
+			return err
+		}
+
+		return i.store.SaveIdempotencyKey(ctx, models.IdempotencyKey{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hash,
+			Response:    payload,
+			ExpiresAt:   time.Now().Add(i.ttl),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrIdempotencyKeyExists) {
+			return fmt.Errorf("%s: %w", op, ErrKeyInProgress)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// keyField is left out of the fingerprint, so the key may travel either in the request or in metadata
+const keyField = "idempotency_key"
+
+// fingerprint hashes the deterministic encoding of the request
+func fingerprint(request proto.Message) ([]byte, error) {
+	m := proto.Clone(request).ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName(keyField); fd != nil {
+		m.Clear(fd)
+	}
+
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(payload)
+
+	return sum[:], nil
+}
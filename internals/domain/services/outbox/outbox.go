@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+type Store interface {
+	OutboxEvents(ctx context.Context, limit int) (events []models.OutboxEvent, err error)
+	DeleteOutboxEvent(ctx context.Context, seq uint64) error
+	RetryOutboxEvent(ctx context.Context, seq uint64, nextAttemptAt time.Time, lastError string) error
+	DeadLetterOutboxEvent(ctx context.Context, seq uint64, lastError string, at time.Time) error
+}
+
+// Sink delivers the events to their consumers, it must be safe to deliver the same event twice
+type Sink interface {
+	Deliver(ctx context.Context, event models.Event) error
+}
+
+// Options control the delivery attempts
+type Options struct {
+	// BatchSize is the amount of events read from the queue at once
+	BatchSize int
+	// MaxAttempts is how many times an event is tried before it goes to the dead letters
+	MaxAttempts int
+	// MinBackoff is the wait after the first failure, it doubles with every next one up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Dispatcher delivers the queued events at least once and in the order they were written
+type Dispatcher struct {
+	log   *slog.Logger
+	store Store
+	sink  Sink
+	opts  Options
+}
+
+func New(log *slog.Logger, store Store, sink Sink, opts Options) *Dispatcher {
+	return &Dispatcher{
+		log:   log,
+		store: store,
+		sink:  sink,
+		opts:  opts,
+	}
+}
+
+// Dispatch delivers the due events of the next batch. It stops at the first event waiting for its retry,
+// so the later events are not delivered before it. more reports whether the batch was full and delivered.
+func (d *Dispatcher) Dispatch(ctx context.Context) (delivered int, more bool, err error) {
+	const op = "outbox.Dispatch"
+
+	log := d.log.With(slog.String("op", op))
+
+	events, err := d.store.OutboxEvents(ctx, d.opts.BatchSize)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, event := range events {
+		now := time.Now()
+		if event.NextAttemptAt.After(now) {
+			return delivered, false, nil
+		}
+
+		deliverErr := d.sink.Deliver(ctx, event.Event)
+		if deliverErr == nil {
+			if err := d.store.DeleteOutboxEvent(ctx, event.Seq); err != nil {
+				return delivered, false, fmt.Errorf("%s: %w", op, err)
+			}
+			delivered++
+			continue
+		}
+
+		eventLog := log.With(
+			slog.String("event_id", event.Event.ID),
+			slog.String("type", event.Event.Type),
+			slog.Int("attempt", event.Attempts+1),
+			slog.String("error", deliverErr.Error()),
+		)
+
+		if event.Attempts+1 >= d.opts.MaxAttempts {
+			eventLog.Error("event moved to the dead letters")
+			if err := d.store.DeadLetterOutboxEvent(ctx, event.Seq, deliverErr.Error(), now); err != nil {
+				return delivered, false, fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+
+		retryAt := now.Add(d.backoff(event.Attempts))
+		eventLog.Warn("event delivery failed", slog.Time("retry_at", retryAt))
+		if err := d.store.RetryOutboxEvent(ctx, event.Seq, retryAt, deliverErr.Error()); err != nil {
+			return delivered, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return delivered, false, nil
+	}
+
+	return delivered, len(events) == d.opts.BatchSize, nil
+}
+
+// backoff is the wait after the failed attempt following the given number of the failed ones
+func (d *Dispatcher) backoff(failed int) time.Duration {
+	wait := d.opts.MinBackoff
+	for i := 0; i < failed && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	return min(wait, d.opts.MaxBackoff)
+}
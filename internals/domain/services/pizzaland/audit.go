@@ -0,0 +1,91 @@
+package pizzaland
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// audited entities
+const (
+	EntityPizza    = "pizza"
+	EntityCategory = "category"
+)
+
+// audited operations
+const (
+	OperationSave    = "save"
+	OperationUpdate  = "update"
+	OperationRemove  = "remove"
+	OperationRestore = "restore"
+)
+
+type Auditor interface {
+	SaveAuditEvent(ctx context.Context, event models.AuditEvent) (id uint64, err error)
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter, offset uint32, limit uint32) (events []models.AuditEvent, err error)
+}
+
+// CallerFunc returns who makes the call of ctx and the id of its request
+type CallerFunc func(ctx context.Context) (actor, requestId string)
+
+// ListAuditEvents returns the catalog changes matching the filter, the newest first
+func (p *DomainPizzaLand) ListAuditEvents(
+	ctx context.Context,
+	filter models.AuditFilter,
+	offset, limit uint32,
+) (events []models.AuditEvent, err error) {
+	const op = "pizzaland.ListAuditEvents"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	events, err = p.auditor.ListAuditEvents(ctx, filter, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list audit events", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// audit appends the change to the audit log and returns the id of its entry,
+// before and after are the JSON of the entity or empty
+func (p *DomainPizzaLand) audit(ctx context.Context, operation, entity string, entityId uint64, before, after string) (uint64, error) {
+	const op = "pizzaland.audit"
+
+	event := models.AuditEvent{
+		OccurredAt: time.Now(),
+		Operation:  operation,
+		Entity:     entity,
+		EntityId:   entityId,
+		Before:     before,
+		After:      after,
+	}
+	event.Actor, event.RequestId = p.caller(ctx)
+
+	id, err := p.auditor.SaveAuditEvent(ctx, event)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func auditJSON(m proto.Message) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,172 @@
+package pizzaland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+)
+
+// errRollback discards the batch transaction without reporting a failure
+var errRollback = errors.New("rollback")
+
+// Transactor binds the storage calls made with the context passed to fn to a single transaction
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Savepoint(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn once the transaction of ctx is committed, right away when there is none
+	AfterCommit(ctx context.Context, fn func())
+}
+
+// BatchOptions control how the batch is applied
+type BatchOptions struct {
+	// BestEffort applies every item on its own instead of the all-or-nothing batch
+	BestEffort bool
+	// ValidateOnly checks every item but rolls the batch back
+	ValidateOnly bool
+}
+
+// BatchResult is the outcome of a single batch item
+type BatchResult struct {
+	PizzaId uint64
+	Pizza   *pizzalndv1.PizzaProperties
+	Err     error
+}
+
+// PizzaPatch is a single item of the batch update, see UpdatePizza
+type PizzaPatch struct {
+	Id      uint64
+	Version uint64
+	Pizza   *pizzalndv1.PizzaProperties
+	Paths   []string
+}
+
+// PizzaRef identifies a single item of the batch removal by id or by name
+type PizzaRef struct {
+	Id      uint64
+	Name    string
+	Version uint64
+}
+
+func (p *DomainPizzaLand) BatchSave(ctx context.Context, pizzas []*pizzalndv1.PizzaProperties, opts BatchOptions) ([]BatchResult, error) {
+	const op = "pizzaland.BatchSave"
+
+	return p.batch(ctx, op, len(pizzas), opts, func(ctx context.Context, i int) BatchResult {
+		if err := pizzas[i].Validate(); err != nil {
+			return BatchResult{Err: fmt.Errorf("%w: %s", ErrInvalidPizza, err.Error())}
+		}
+
+		id, err := p.Save(ctx, pizzas[i])
+
+		return BatchResult{PizzaId: id, Err: err}
+	})
+}
+
+func (p *DomainPizzaLand) BatchUpdate(ctx context.Context, patches []PizzaPatch, opts BatchOptions) ([]BatchResult, error) {
+	const op = "pizzaland.BatchUpdate"
+
+	return p.batch(ctx, op, len(patches), opts, func(ctx context.Context, i int) BatchResult {
+		patch := patches[i]
+
+		pizza, err := p.UpdatePizza(ctx, patch.Id, patch.Version, patch.Pizza, patch.Paths)
+
+		return BatchResult{PizzaId: patch.Id, Pizza: pizza, Err: err}
+	})
+}
+
+func (p *DomainPizzaLand) BatchRemove(ctx context.Context, refs []PizzaRef, opts BatchOptions) ([]BatchResult, error) {
+	const op = "pizzaland.BatchRemove"
+
+	return p.batch(ctx, op, len(refs), opts, func(ctx context.Context, i int) BatchResult {
+		ref := refs[i]
+
+		req := &pizzalndv1.RemoveRequest{Version: ref.Version}
+		switch {
+		case ref.Id != 0:
+			req.Identifier = &pizzalndv1.RemoveRequest_PizzaId{PizzaId: ref.Id}
+		case ref.Name != "":
+			req.Identifier = &pizzalndv1.RemoveRequest_PizzaName{PizzaName: ref.Name}
+		default:
+			return BatchResult{Err: fmt.Errorf("%w: neither the id nor the name was given", ErrInvalidPizzaRef)}
+		}
+		if err := req.Validate(); err != nil {
+			return BatchResult{PizzaId: ref.Id, Err: fmt.Errorf("%w: %s", ErrInvalidPizzaRef, err.Error())}
+		}
+
+		var err error
+		if ref.Id != 0 {
+			_, err = p.RemoveById(ctx, ref.Id, ref.Version)
+		} else {
+			_, err = p.RemoveByName(ctx, ref.Name, ref.Version)
+		}
+
+		return BatchResult{PizzaId: ref.Id, Err: err}
+	})
+}
+
+// batch applies n items in a single transaction according to opts.
+// The all-or-nothing batch returns the error of the first failed item prefixed with its index,
+// the best-effort one fails as a whole only when the savepoint of an item does.
+func (p *DomainPizzaLand) batch(
+	ctx context.Context,
+	op string,
+	n int,
+	opts BatchOptions,
+	apply func(ctx context.Context, i int) BatchResult,
+) (results []BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	log := p.logger(ctx).With(
+		slog.String("op", op),
+		slog.Int("items", n),
+		slog.Bool("best_effort", opts.BestEffort),
+		slog.Bool("validate_only", opts.ValidateOnly),
+	)
+
+	results = make([]BatchResult, n)
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		for i := range results {
+			if !opts.BestEffort {
+				if results[i] = apply(ctx, i); results[i].Err != nil {
+					return fmt.Errorf("item %d: %w", i, results[i].Err)
+				}
+				continue
+			}
+
+			err := p.tx.Savepoint(ctx, func(ctx context.Context) error {
+				results[i] = apply(ctx, i)
+				return results[i].Err
+			})
+			if err != nil && err != results[i].Err {
+				// the savepoint itself failed, so the item is neither applied nor rolled back as reported
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+
+		if opts.ValidateOnly {
+			return errRollback
+		}
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errRollback):
+		// nothing was written, the identifiers and values are not real
+		for i := range results {
+			results[i].PizzaId, results[i].Pizza = 0, nil
+		}
+	case err != nil:
+		log.Warn("batch rolled back", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("batch applied")
+
+	return results, nil
+}
@@ -0,0 +1,175 @@
+package pizzaland_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+)
+
+// failingSavepoint fails the savepoint of the item with the index before running it
+type failingSavepoint struct {
+	*sqlite.Storage
+	item  int
+	calls int
+}
+
+func (f *failingSavepoint) Savepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	defer func() { f.calls++ }()
+
+	if f.calls == f.item {
+		return errors.New("savepoint failed")
+	}
+
+	return f.Storage.Savepoint(ctx, fn)
+}
+
+// newPizzaLand returns the service on the migrated storage with the Classic category,
+// the transactions are run by the storage wrapped with wrap when it is given
+func newPizzaLand(t *testing.T, wrap func(s *sqlite.Storage) pizzaland.Transactor) (*pizzaland.DomainPizzaLand, *sqlite.Storage, uint32) {
+	t.Helper()
+
+	s := sqlitetest.New(t)
+	var tx pizzaland.Transactor = s
+	if wrap != nil {
+		tx = wrap(s)
+	}
+
+	caller := func(context.Context) (string, string) { return "test", "" }
+	p := pizzaland.NewPizzaLand(slog.New(slog.NewTextHandler(io.Discard, nil)), s, s, s, s, tx, s, caller, nil, nil, nil)
+
+	categoryId, err := p.SaveCategory(context.Background(), &pizzalndv1.CategoryProperties{Name: "Classic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p, s, categoryId
+}
+
+// items are two valid pizzas around the one of the missing category
+func items(categoryId uint32) []*pizzalndv1.PizzaProperties {
+	return []*pizzalndv1.PizzaProperties{
+		pizza("Margherita", categoryId),
+		pizza("Pepperoni", 999),
+		pizza("Hawaiian", categoryId),
+	}
+}
+
+func TestBatchAllOrNothing(t *testing.T) {
+	p, s, categoryId := newPizzaLand(t, nil)
+
+	_, err := p.BatchSave(context.Background(), items(categoryId), pizzaland.BatchOptions{})
+	if !errors.Is(err, pizzaland.ErrCategoryNotFound) {
+		t.Fatalf("batch with the missing category: %v, want ErrCategoryNotFound", err)
+	}
+
+	assertNames(t, s)
+
+	results, err := p.BatchSave(context.Background(), items(categoryId)[:1], pizzaland.BatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != nil || results[0].PizzaId == 0 {
+		t.Errorf("result %+v, want the saved pizza", results[0])
+	}
+
+	assertNames(t, s, "Margherita")
+}
+
+func TestBatchBestEffort(t *testing.T) {
+	p, s, categoryId := newPizzaLand(t, nil)
+
+	results, err := p.BatchSave(context.Background(), items(categoryId), pizzaland.BatchOptions{BestEffort: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []error{nil, pizzaland.ErrCategoryNotFound, nil} {
+		if !errors.Is(results[i].Err, want) {
+			t.Errorf("item %d: %v, want %v", i, results[i].Err, want)
+		}
+		if want == nil && results[i].PizzaId == 0 {
+			t.Errorf("item %d has no id", i)
+		}
+	}
+
+	assertNames(t, s, "Margherita", "Hawaiian")
+}
+
+func TestBatchBestEffortSavepointFails(t *testing.T) {
+	p, s, categoryId := newPizzaLand(t, func(s *sqlite.Storage) pizzaland.Transactor {
+		return &failingSavepoint{Storage: s, item: 1}
+	})
+
+	if _, err := p.BatchSave(context.Background(), items(categoryId), pizzaland.BatchOptions{BestEffort: true}); err == nil {
+		t.Fatal("the batch succeeded without the savepoint of an item")
+	}
+
+	assertNames(t, s)
+}
+
+func TestBatchValidateOnly(t *testing.T) {
+	for _, bestEffort := range []bool{false, true} {
+		p, s, categoryId := newPizzaLand(t, nil)
+
+		opts := pizzaland.BatchOptions{BestEffort: bestEffort, ValidateOnly: true}
+		results, err := p.BatchSave(context.Background(), items(categoryId)[:1], opts)
+		if err != nil {
+			t.Fatalf("best effort %t: %v", bestEffort, err)
+		}
+		if results[0].Err != nil || results[0].PizzaId != 0 {
+			t.Errorf("best effort %t: result %+v, want no error and no id", bestEffort, results[0])
+		}
+
+		// the failed items are still reported
+		results, err = p.BatchSave(context.Background(), items(categoryId), opts)
+		if bestEffort {
+			if err != nil || !errors.Is(results[1].Err, pizzaland.ErrCategoryNotFound) {
+				t.Errorf("best effort: %v, item 1 %v, want ErrCategoryNotFound of the item", err, results[1].Err)
+			}
+		} else if !errors.Is(err, pizzaland.ErrCategoryNotFound) {
+			t.Errorf("all or nothing: %v, want ErrCategoryNotFound", err)
+		}
+
+		assertNames(t, s)
+	}
+}
+
+// assertNames checks the live pizzas are the named ones in the order of their ids
+func assertNames(t *testing.T, s *sqlite.Storage, want ...string) {
+	t.Helper()
+
+	pizzas, err := s.List(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, pizza := range pizzas {
+		got = append(got, pizza.GetName())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("pizzas %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pizzas %q, want %q", got, want)
+		}
+	}
+}
+
+func pizza(name string, categoryId uint32) *pizzalndv1.PizzaProperties {
+	return &pizzalndv1.PizzaProperties{
+		CategoryId: categoryId,
+		Name:       name,
+		TypeDough:  pizzalndv1.TypeDough_THIN_DOUGH,
+		Price:      590,
+		Diameter:   30,
+	}
+}
@@ -0,0 +1,272 @@
+package pizzaland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/names"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ImportMenu applies the categories and then the pizzas of the menu file in a single transaction.
+// Every row is checked with the validation rules of the API; the first failing row does not stop the others,
+// but nothing is written when any of them failed or with DryRun. With Upsert the categories and pizzas
+// found by name are replaced by the rows, otherwise they are reported as existing.
+func (p *DomainPizzaLand) ImportMenu(ctx context.Context, rows []models.MenuRow, opts models.ImportOptions) (summary models.ImportSummary, err error) {
+	const op = "pizzaland.ImportMenu"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	log := p.logger(ctx).With(
+		slog.String("op", op),
+		slog.Int("rows", len(rows)),
+		slog.Bool("dry_run", opts.DryRun),
+		slog.Bool("upsert", opts.Upsert),
+	)
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		// the categories of the file by name key, so its pizzas may refer to them before they are written
+		categories := make(map[string]uint32)
+
+		for _, kind := range []string{models.MenuRowCategory, models.MenuRowPizza} {
+			for _, row := range rows {
+				if row.Kind != kind {
+					continue
+				}
+
+				var rowErrs []models.ImportError
+				err := p.tx.Savepoint(ctx, func(ctx context.Context) (err error) {
+					if kind == models.MenuRowCategory {
+						rowErrs, err = p.importCategory(ctx, row, opts, categories, &summary)
+					} else {
+						rowErrs, err = p.importPizza(ctx, row, opts, categories, &summary)
+					}
+					if err == nil && len(rowErrs) != 0 {
+						return errRollback
+					}
+
+					return err
+				})
+				if err != nil && !errors.Is(err, errRollback) {
+					return fmt.Errorf("row %d: %w", row.Row, err)
+				}
+				summary.Errors = append(summary.Errors, rowErrs...)
+			}
+		}
+
+		if opts.DryRun || len(summary.Errors) != 0 {
+			return errRollback
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		log.Error("failed to import menu", slog.String("error", err.Error()))
+		return models.ImportSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	summary.Applied = err == nil
+	log.Info("menu imported",
+		slog.Bool("applied", summary.Applied),
+		slog.Int("categories_created", summary.CategoriesCreated),
+		slog.Int("categories_updated", summary.CategoriesUpdated),
+		slog.Int("pizzas_created", summary.PizzasCreated),
+		slog.Int("pizzas_updated", summary.PizzasUpdated),
+		slog.Int("errors", len(summary.Errors)),
+	)
+
+	return summary, nil
+}
+
+// importCategory saves or, with Upsert, updates the category of the row. The row problems are returned
+// as rowErrs, err fails the whole import.
+func (p *DomainPizzaLand) importCategory(
+	ctx context.Context,
+	row models.MenuRow,
+	opts models.ImportOptions,
+	categories map[string]uint32,
+	summary *models.ImportSummary,
+) (rowErrs []models.ImportError, err error) {
+	category := &pizzalndv1.CategoryProperties{Name: row.Name}
+	if row.Description != "" {
+		category.Description = wrapperspb.String(row.Description)
+	}
+
+	if err := category.ValidateAll(); err != nil {
+		return validationErrors(row, err), nil
+	}
+
+	key := names.Key(row.Name)
+	if _, ok := categories[key]; ok {
+		return rowErrors(row, "name", "the category is already in the file"), nil
+	}
+
+	if opts.Upsert {
+		current, err := p.getter.GetCategoryByName(ctx, row.Name)
+		switch {
+		case err == nil:
+			id := current.GetCategoryId().GetValue()
+			categories[key] = id
+
+			if current.GetDescription().GetValue() == row.Description {
+				summary.Unchanged++
+				return nil, nil
+			}
+
+			description := row.Description
+			if _, err := p.UpdateCategory(ctx, id, 0, models.CategoryUpdate{Description: &description}); err != nil {
+				return importErrors(row, err)
+			}
+			summary.CategoriesUpdated++
+
+			return nil, nil
+		case !errors.Is(err, storage.ErrCategoryNotFound):
+			return nil, err
+		}
+	}
+
+	id, err := p.SaveCategory(ctx, category)
+	if err != nil {
+		return importErrors(row, err)
+	}
+	categories[key] = id
+	summary.CategoriesCreated++
+
+	return nil, nil
+}
+
+// importPizza saves or, with Upsert, replaces the pizza of the row
+func (p *DomainPizzaLand) importPizza(
+	ctx context.Context,
+	row models.MenuRow,
+	opts models.ImportOptions,
+	categories map[string]uint32,
+	summary *models.ImportSummary,
+) (rowErrs []models.ImportError, err error) {
+	if row.Category == "" {
+		return rowErrors(row, "category", "the category of the pizza is required"), nil
+	}
+
+	categoryId, ok := categories[names.Key(row.Category)]
+	if !ok {
+		category, err := p.getter.GetCategoryByName(ctx, row.Category)
+		switch {
+		case errors.Is(err, storage.ErrCategoryNotFound):
+			return rowErrors(row, "category", fmt.Sprintf("category %q is neither in the file nor in the menu", row.Category)), nil
+		case err != nil:
+			return nil, err
+		}
+		categoryId = category.GetCategoryId().GetValue()
+		categories[names.Key(row.Category)] = categoryId
+	}
+
+	pizza := &pizzalndv1.PizzaProperties{
+		CategoryId: categoryId,
+		Name:       row.Name,
+		TypeDough:  pizzalndv1.TypeDough(row.TypeDough),
+		Price:      row.Price,
+		Diameter:   row.Diameter,
+	}
+	if row.Description != "" {
+		pizza.Description = wrapperspb.String(row.Description)
+	}
+
+	if err := pizza.ValidateAll(); err != nil {
+		return validationErrors(row, err), nil
+	}
+
+	if opts.Upsert {
+		current, err := p.getter.GetByName(ctx, row.Name)
+		switch {
+		case err == nil:
+			_, err := p.UpdatePizza(ctx, current.GetPizzaId().GetValue(), 0, pizza, []string{maskWildcard})
+			switch {
+			case errors.Is(err, ErrNothingToUpdate):
+				summary.Unchanged++
+				return nil, nil
+			case err != nil:
+				return importErrors(row, err)
+			}
+			summary.PizzasUpdated++
+
+			return nil, nil
+		case !errors.Is(err, storage.ErrPizzaNotFound):
+			return nil, err
+		}
+	}
+
+	if _, err := p.Save(ctx, pizza); err != nil {
+		return importErrors(row, err)
+	}
+	summary.PizzasCreated++
+
+	return nil, nil
+}
+
+// importErrors reports the domain errors caused by the row itself, the other ones fail the import
+func importErrors(row models.MenuRow, err error) ([]models.ImportError, error) {
+	switch {
+	case errors.Is(err, ErrCategoryExists):
+		return rowErrors(row, "name", "category already exists"), nil
+	case errors.Is(err, ErrPizzaExists):
+		return rowErrors(row, "name", "pizza already exists"), nil
+	case errors.Is(err, ErrInvalidPizza):
+		return rowErrors(row, "", err.Error()), nil
+	case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrPizzaNotFound):
+		return rowErrors(row, "", err.Error()), nil
+	}
+
+	return nil, err
+}
+
+func rowErrors(row models.MenuRow, field, message string) []models.ImportError {
+	return []models.ImportError{{Row: row.Row, Line: row.Line, Field: field, Message: message}}
+}
+
+// validationErrors splits the protoc-gen-validate errors of the row by field
+func validationErrors(row models.MenuRow, err error) []models.ImportError {
+	var multi interface{ AllErrors() []error }
+	if !errors.As(err, &multi) {
+		return rowErrors(row, "", err.Error())
+	}
+
+	var out []models.ImportError
+	for _, err := range multi.AllErrors() {
+		fieldErr, ok := err.(interface {
+			Field() string
+			Reason() string
+		})
+		if !ok {
+			out = append(out, rowErrors(row, "", err.Error())...)
+			continue
+		}
+		out = append(out, rowErrors(row, snakeCase(fieldErr.Field()), fieldErr.Reason())...)
+	}
+
+	return out
+}
+
+// snakeCase turns the Go field names of the validation errors into the names of the menu file
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
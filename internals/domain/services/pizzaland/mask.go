@@ -0,0 +1,162 @@
+package pizzaland
+
+import (
+	"fmt"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// maskWildcard replaces every updatable field of the resource
+const maskWildcard = "*"
+
+// updatablePizzaFields lists the PizzaProperties fields accepted in the update mask.
+// Output only and identifier fields are never written by an update.
+var updatablePizzaFields = []protoreflect.Name{
+	"category_id",
+	"name",
+	"description",
+	"type_dough",
+	"price",
+	"diameter",
+}
+
+// pizzaMaskPaths resolves the update mask into the list of fields to write.
+// Empty mask means every populated updatable field of the pizza.
+func pizzaMaskPaths(pizza *pizzalndv1.PizzaProperties, paths []string) ([]protoreflect.Name, error) {
+	switch {
+	case len(paths) == 0:
+		msg := pizza.ProtoReflect()
+		fields := msg.Descriptor().Fields()
+
+		resolved := make([]protoreflect.Name, 0, len(updatablePizzaFields))
+		for _, name := range updatablePizzaFields {
+			if msg.Has(fields.ByName(name)) {
+				resolved = append(resolved, name)
+			}
+		}
+
+		return resolved, nil
+	case len(paths) == 1 && paths[0] == maskWildcard:
+		return updatablePizzaFields, nil
+	}
+
+	resolved := make([]protoreflect.Name, 0, len(paths))
+	for _, path := range paths {
+		name := protoreflect.Name(path)
+		if !isUpdatablePizzaField(name) {
+			return nil, fmt.Errorf("%w: %q is not an updatable field", ErrInvalidUpdateMask, path)
+		}
+		resolved = append(resolved, name)
+	}
+
+	return resolved, nil
+}
+
+// applyPizzaMask copies the masked fields from src to dst, clearing the ones unset in src
+func applyPizzaMask(dst, src *pizzalndv1.PizzaProperties, fields []protoreflect.Name) {
+	from, to := src.ProtoReflect(), dst.ProtoReflect()
+	descriptors := from.Descriptor().Fields()
+
+	for _, name := range fields {
+		fd := descriptors.ByName(name)
+		if from.Has(fd) {
+			to.Set(fd, from.Get(fd))
+		} else {
+			to.Clear(fd)
+		}
+	}
+}
+
+// pizzaUpdate builds the storage update from the masked fields of the pizza
+func pizzaUpdate(pizza *pizzalndv1.PizzaProperties, fields []protoreflect.Name) models.PizzaUpdate {
+	var update models.PizzaUpdate
+
+	for _, name := range fields {
+		switch name {
+		case "category_id":
+			v := pizza.GetCategoryId()
+			update.CategoryId = &v
+		case "name":
+			v := pizza.GetName()
+			update.Name = &v
+		case "description":
+			// empty description clears the column
+			v := pizza.GetDescription().GetValue()
+			update.Description = &v
+		case "type_dough":
+			v := int32(pizza.GetTypeDough())
+			update.TypeDough = &v
+		case "price":
+			v := pizza.GetPrice()
+			update.Price = &v
+		case "diameter":
+			v := pizza.GetDiameter()
+			update.Diameter = &v
+		}
+	}
+
+	return update
+}
+
+func isUpdatablePizzaField(name protoreflect.Name) bool {
+	for _, field := range updatablePizzaFields {
+		if field == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergePizzaUpdate returns the pizza as it is after the update is written
+func mergePizzaUpdate(pizza *pizzalndv1.PizzaProperties, update models.PizzaUpdate) *pizzalndv1.PizzaProperties {
+	merged := proto.Clone(pizza).(*pizzalndv1.PizzaProperties)
+
+	if update.CategoryId != nil {
+		merged.CategoryId = *update.CategoryId
+	}
+	if update.Name != nil {
+		merged.Name = *update.Name
+	}
+	if update.Description != nil {
+		merged.Description = description(*update.Description)
+	}
+	if update.TypeDough != nil {
+		merged.TypeDough = pizzalndv1.TypeDough(*update.TypeDough)
+	}
+	if update.Price != nil {
+		merged.Price = *update.Price
+	}
+	if update.Diameter != nil {
+		merged.Diameter = *update.Diameter
+	}
+
+	return merged
+}
+
+// mergeCategoryUpdate returns the category as it is after the update is written
+func mergeCategoryUpdate(category *pizzalndv1.CategoryProperties, update models.CategoryUpdate) *pizzalndv1.CategoryProperties {
+	merged := proto.Clone(category).(*pizzalndv1.CategoryProperties)
+
+	if update.Name != nil {
+		merged.Name = *update.Name
+	}
+	if update.Description != nil {
+		merged.Description = description(*update.Description)
+	}
+
+	return merged
+}
+
+// description is the stored description, the empty one clears the column
+func description(v string) *wrapperspb.StringValue {
+	if v == "" {
+		return nil
+	}
+
+	return wrapperspb.String(v)
+}
@@ -0,0 +1,109 @@
+package pizzaland
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/protobuf/proto"
+)
+
+// EventSource is the source of the change events
+const EventSource = "/pizzaland"
+
+// eventVerbs name the change events after the operations, e.g. "pizzaland.pizza.created"
+var eventVerbs = map[string]string{
+	OperationSave:    "created",
+	OperationUpdate:  "updated",
+	OperationRemove:  "deleted",
+	OperationRestore: "restored",
+}
+
+// Outbox queues the change events for the delivery
+type Outbox interface {
+	SaveOutboxEvent(ctx context.Context, event models.Event) error
+}
+
+// Webhooks queues the change events for the webhooks subscribed to their types
+type Webhooks interface {
+	SaveWebhookDeliveries(ctx context.Context, event models.Event) error
+}
+
+// changeData is the data of the change events
+type changeData struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// recordChange writes the audit entry and queues the change event for the outbox and the webhooks,
+// before and after are nil when the entity is not live.
+// Call it in the transaction of the change, so the change, its entry and its event are written together.
+// The watchers of the menu get the change once the transaction is committed.
+func (p *DomainPizzaLand) recordChange(ctx context.Context, operation, entity string, entityId uint64, before, after proto.Message) error {
+	const op = "pizzaland.recordChange"
+
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	seq, err := p.audit(ctx, operation, entity, entityId, beforeJSON, afterJSON)
+	if err != nil {
+		return err
+	}
+
+	if p.menu != nil {
+		change := MenuChange{Before: before, After: after}
+		p.tx.AfterCommit(ctx, func() { p.menu.Publish(seq, change) })
+	}
+
+	if p.outbox == nil && p.webhooks == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(changeData{Before: rawJSON(beforeJSON), After: rawJSON(afterJSON)})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	event := models.Event{
+		SpecVersion:     models.EventSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          EventSource,
+		Type:            "pizzaland." + entity + "." + eventVerbs[operation],
+		Subject:         entity + "/" + strconv.FormatUint(entityId, 10),
+		Time:            time.Now(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+
+	if p.outbox != nil {
+		if err := p.outbox.SaveOutboxEvent(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if p.webhooks != nil {
+		if err := p.webhooks.SaveWebhookDeliveries(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+
+	return json.RawMessage(s)
+}
@@ -2,9 +2,36 @@ package pizzaland
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/lib/reflection"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/protobuf/proto"
+)
+
+var tracer = otel.Tracer("github.com/nhassl3/pizzaland/internals/domain/services/pizzaland")
+
+// categoryPageSize is the amount of pizza returned for the single category lookup
+const categoryPageSize = 12
+
+var (
+	ErrPizzaNotFound     = errors.New("pizza not found")
+	ErrPizzaExists       = errors.New("pizza already exists")
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategoryExists    = errors.New("category already exists")
+	ErrVersionMismatch   = errors.New("version does not match the current one")
+	ErrNothingToUpdate   = errors.New("nothing to update")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+	ErrInvalidPizza      = errors.New("invalid pizza")
+	ErrInvalidPizzaRef   = errors.New("invalid pizza reference")
 )
 
 type Saver interface {
@@ -19,26 +46,25 @@ type Getter interface {
 	GetCategoryByName(ctx context.Context, name string) (category *pizzalndv1.CategoryProperties, err error)
 	List(ctx context.Context, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
 	ListCategory(ctx context.Context, name string, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
+	ListCategoryById(ctx context.Context, id uint32, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
+	ListDeleted(ctx context.Context, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
+	ListCategories(ctx context.Context, offset uint32, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error)
+	ListDeletedCategories(ctx context.Context, offset uint32, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error)
 }
 
 type Remover interface {
-	RemoveById(ctx context.Context, id uint64) (success bool, err error)
-	RemoveByName(ctx context.Context, name string) (success bool, err error)
-	RemoveCategoryById(ctx context.Context, id uint64) (success bool, err error)
-	RemoveCategoryByName(ctx context.Context, name string) (success bool, err error)
+	RemoveById(ctx context.Context, id uint64, version uint64) (success bool, err error)
+	RemoveByName(ctx context.Context, name string, version uint64) (success bool, err error)
+	RemoveCategoryById(ctx context.Context, id uint64, version uint64) (success bool, err error)
+	RemoveCategoryByName(ctx context.Context, name string, version uint64) (success bool, err error)
+	Purge(ctx context.Context, before time.Time) (pizzas, categories int64, err error)
 }
 
 type Updater interface {
-	Update(
-		ctx context.Context,
-		categoryId uint32,
-		name string,
-		description string,
-		typeDough pizzalndv1.TypeDough,
-		price float64,
-		diameter uint32,
-	) (success bool, err error)
-	UpdateCategory(ctx context.Context, name string, descriptions string) (success bool, err error)
+	Update(ctx context.Context, id uint64, version uint64, update models.PizzaUpdate) (success bool, err error)
+	UpdateCategory(ctx context.Context, id uint32, version uint64, update models.CategoryUpdate) (success bool, err error)
+	Restore(ctx context.Context, id uint64) (success bool, err error)
+	RestoreCategory(ctx context.Context, id uint32) (success bool, err error)
 }
 
 type DomainPizzaLand struct {
@@ -47,6 +73,15 @@ type DomainPizzaLand struct {
 	getter  Getter
 	remover Remover
 	updater Updater
+	tx      Transactor
+	auditor Auditor
+	caller  CallerFunc
+	// outbox is nil when the change events are not delivered
+	outbox Outbox
+	// webhooks is nil when the webhooks are not called
+	webhooks Webhooks
+	// menu is nil when the menu is not watched
+	menu *MenuHub
 }
 
 func NewPizzaLand(
@@ -55,71 +90,645 @@ func NewPizzaLand(
 	getter Getter,
 	remover Remover,
 	updater Updater,
+	tx Transactor,
+	auditor Auditor,
+	caller CallerFunc,
+	outbox Outbox,
+	webhooks Webhooks,
+	menu *MenuHub,
 ) *DomainPizzaLand {
 	return &DomainPizzaLand{
-		log:     log,
-		saver:   saver,
-		getter:  getter,
-		remover: remover,
-		updater: updater,
+		log:      log,
+		saver:    saver,
+		getter:   getter,
+		remover:  remover,
+		updater:  updater,
+		tx:       tx,
+		auditor:  auditor,
+		caller:   caller,
+		outbox:   outbox,
+		webhooks: webhooks,
+		menu:     menu,
 	}
 }
 
 func (p *DomainPizzaLand) Save(ctx context.Context, pizza *pizzalndv1.PizzaProperties) (pizzaId uint64, err error) {
-	panic("implement me")
+	const op = "pizzaland.Save"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	log := p.logger(ctx).With(slog.String("op", op), slog.String("name", pizza.GetName()))
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		if pizzaId, err = p.saver.Save(ctx, pizza); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetById(ctx, pizzaId)
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationSave, EntityPizza, pizzaId, nil, after)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrPizzaExists) {
+			log.Warn("pizza already exists", slog.String("error", err.Error()))
+			return 0, fmt.Errorf("%s: %w", op, ErrPizzaExists)
+		}
+
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			log.Warn("category of the pizza not found", slog.Uint64("category_id", uint64(pizza.GetCategoryId())))
+			return 0, fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+		}
+
+		log.Error("failed to save pizza", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("pizza saved", slog.Uint64("pizza_id", pizzaId))
+
+	return pizzaId, nil
 }
 
 func (p *DomainPizzaLand) GetById(ctx context.Context, id uint64) (pizza *pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.GetById"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.GetById(ctx, id)
+	if err != nil {
+		return nil, p.pizzaError(ctx, op, err)
+	}
+
+	return pizza, nil
 }
 
 func (p *DomainPizzaLand) GetByName(ctx context.Context, name string) (pizza *pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.GetByName"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.GetByName(ctx, name)
+	if err != nil {
+		return nil, p.pizzaError(ctx, op, err)
+	}
+
+	return pizza, nil
+}
+
+// PizzaCategory returns the category of the live pizza with the id or, when the id is zero, with the name
+func (p *DomainPizzaLand) PizzaCategory(ctx context.Context, id uint64, name string) (categoryId uint32, err error) {
+	const op = "pizzaland.PizzaCategory"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	var pizza *pizzalndv1.PizzaProperties
+	if id != 0 {
+		pizza, err = p.getter.GetById(ctx, id)
+	} else {
+		pizza, err = p.getter.GetByName(ctx, name)
+	}
+	if err != nil {
+		return 0, p.pizzaError(ctx, op, err)
+	}
+
+	return pizza.GetCategoryId(), nil
 }
 
 func (p *DomainPizzaLand) List(ctx context.Context, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.List"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.List(ctx, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list pizza", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
 }
 
 func (p *DomainPizzaLand) CategoryList(ctx context.Context, category string, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.CategoryList"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.ListCategory(ctx, category, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list pizza of category", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
 }
 
-func (p *DomainPizzaLand) Update(
+// CategoryListById returns the pizzas of the category with the id
+func (p *DomainPizzaLand) CategoryListById(ctx context.Context, categoryId uint32, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
+	const op = "pizzaland.CategoryListById"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.ListCategoryById(ctx, categoryId, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list pizza of category", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
+}
+
+func (p *DomainPizzaLand) ListDeleted(ctx context.Context, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
+	const op = "pizzaland.ListDeleted"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizza, err = p.getter.ListDeleted(ctx, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list removed pizza", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
+}
+
+// Update changes the given pizza fields, non-zero version must match the current one
+func (p *DomainPizzaLand) Update(ctx context.Context, id uint64, version uint64, update models.PizzaUpdate) (success bool, err error) {
+	const op = "pizzaland.Update"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	if update.IsEmpty() {
+		return false, fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+	}
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(reflection.Diff(before, mergePizzaUpdate(before, update))) == 0 {
+			return ErrNothingToUpdate
+		}
+
+		if success, err = p.updater.Update(ctx, id, version, update); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationUpdate, EntityPizza, id, before, after)
+	})
+	if err != nil {
+		return false, p.pizzaError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("pizza updated", slog.String("op", op), slog.Uint64("pizza_id", id))
+
+	return success, nil
+}
+
+// UpdatePizza writes the masked fields of the pizza and returns the updated one.
+// The stored pizza with the mask applied must pass the pizza validation rules.
+func (p *DomainPizzaLand) UpdatePizza(
 	ctx context.Context,
-	categoryId uint32,
-	name, description string,
-	typeDough *pizzalndv1.TypeDough,
-	price float32,
-	diameter uint32,
-) (success bool, err error) {
-	panic("implement me")
+	id uint64,
+	version uint64,
+	pizza *pizzalndv1.PizzaProperties,
+	paths []string,
+) (updated *pizzalndv1.PizzaProperties, err error) {
+	const op = "pizzaland.UpdatePizza"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	fields, err := pizzaMaskPaths(pizza, paths)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+	}
+
+	var changes []reflection.Change
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		current, err := p.getter.GetById(ctx, id)
+		if err != nil {
+			return p.pizzaError(ctx, op, err)
+		}
+
+		merged := proto.Clone(current).(*pizzalndv1.PizzaProperties)
+		applyPizzaMask(merged, pizza, fields)
+
+		if err := merged.Validate(); err != nil {
+			return fmt.Errorf("%s: %w: %s", op, ErrInvalidPizza, err.Error())
+		}
+
+		if changes = reflection.Diff(current, merged); len(changes) == 0 {
+			return fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+		}
+
+		if _, err := p.updater.Update(ctx, id, version, pizzaUpdate(merged, fields)); err != nil {
+			return p.pizzaError(ctx, op, err)
+		}
+
+		if updated, err = p.getter.GetById(ctx, id); err != nil {
+			return p.pizzaError(ctx, op, err)
+		}
+
+		if err := p.recordChange(ctx, OperationUpdate, EntityPizza, id, current, updated); err != nil {
+			return p.pizzaError(ctx, op, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger(ctx).Info("pizza updated", slog.String("op", op), slog.Uint64("pizza_id", id), slog.Any("changes", changes))
+
+	return updated, nil
+}
+
+func (p *DomainPizzaLand) RemoveById(ctx context.Context, id uint64, version uint64) (success bool, err error) {
+	const op = "pizzaland.RemoveById"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if success, err = p.remover.RemoveById(ctx, id, version); err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRemove, EntityPizza, id, before, nil)
+	})
+	if err != nil {
+		return false, p.pizzaError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("pizza moved to the trash", slog.String("op", op), slog.Uint64("pizza_id", id))
+
+	return success, nil
 }
 
-func (p *DomainPizzaLand) RemoveById(ctx context.Context, id uint64) (success bool, err error) {
-	panic("implement me")
+func (p *DomainPizzaLand) RemoveByName(ctx context.Context, name string, version uint64) (success bool, err error) {
+	const op = "pizzaland.RemoveByName"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetByName(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if success, err = p.remover.RemoveByName(ctx, name, version); err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRemove, EntityPizza, before.GetPizzaId().GetValue(), before, nil)
+	})
+	if err != nil {
+		return false, p.pizzaError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("pizza moved to the trash", slog.String("op", op), slog.String("name", name))
+
+	return success, nil
 }
 
-func (p *DomainPizzaLand) RemoveByName(ctx context.Context, name string) (success bool, err error) {
-	panic("implement me")
+func (p *DomainPizzaLand) Restore(ctx context.Context, id uint64) (success bool, err error) {
+	const op = "pizzaland.Restore"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		if success, err = p.updater.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRestore, EntityPizza, id, nil, after)
+	})
+	if err != nil {
+		return false, p.pizzaError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("pizza restored from the trash", slog.String("op", op), slog.Uint64("pizza_id", id))
+
+	return success, nil
 }
 
-func (p *DomainPizzaLand) SaveCategory(ctx context.Context, category *pizzalndv1.CategoryProperties) (uint32 uint32, err error) {
-	panic("implement me")
+func (p *DomainPizzaLand) SaveCategory(ctx context.Context, category *pizzalndv1.CategoryProperties) (categoryId uint32, err error) {
+	const op = "pizzaland.SaveCategory"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	log := p.logger(ctx).With(slog.String("op", op), slog.String("name", category.GetName()))
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		if categoryId, err = p.saver.SaveCategory(ctx, category); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetCategoryById(ctx, uint64(categoryId))
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationSave, EntityCategory, uint64(categoryId), nil, after)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrCategoryExists) {
+			log.Warn("category already exists", slog.String("error", err.Error()))
+			return 0, fmt.Errorf("%s: %w", op, ErrCategoryExists)
+		}
+
+		log.Error("failed to save category", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("category saved", slog.Uint64("category_id", uint64(categoryId)))
+
+	return categoryId, nil
 }
 
 func (p *DomainPizzaLand) GetCategoryById(ctx context.Context, id uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.GetCategoryById"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	category, err := p.getter.GetCategoryById(ctx, uint64(id))
+	if err != nil {
+		return nil, p.categoryError(ctx, op, err)
+	}
+
+	return p.CategoryList(ctx, category.GetName(), 0, categoryPageSize)
 }
 
 func (p *DomainPizzaLand) GetCategoryByName(ctx context.Context, name string) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "pizzaland.GetCategoryByName"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := p.getter.GetCategoryByName(ctx, name); err != nil {
+		return nil, p.categoryError(ctx, op, err)
+	}
+
+	return p.CategoryList(ctx, name, 0, categoryPageSize)
+}
+
+// CategoryId returns the id of the live category with the name
+func (p *DomainPizzaLand) CategoryId(ctx context.Context, name string) (categoryId uint32, err error) {
+	const op = "pizzaland.CategoryId"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	category, err := p.getter.GetCategoryByName(ctx, name)
+	if err != nil {
+		return 0, p.categoryError(ctx, op, err)
+	}
+
+	return category.GetCategoryId().GetValue(), nil
 }
 
-func (p *DomainPizzaLand) UpdateCategory(ctx context.Context, name, descriptions string) (success bool, err error) {
-	panic("implement me")
+func (p *DomainPizzaLand) ListDeletedCategories(ctx context.Context, offset, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error) {
+	const op = "pizzaland.ListDeletedCategories"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	categories, err = p.getter.ListDeletedCategories(ctx, offset, limit)
+	if err != nil {
+		p.logger(ctx).Error("failed to list removed categories", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory changes the given category fields, non-zero version must match the current one
+func (p *DomainPizzaLand) UpdateCategory(ctx context.Context, id uint32, version uint64, update models.CategoryUpdate) (success bool, err error) {
+	const op = "pizzaland.UpdateCategory"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	if update.IsEmpty() {
+		return false, fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+	}
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetCategoryById(ctx, uint64(id))
+		if err != nil {
+			return err
+		}
+
+		if len(reflection.Diff(before, mergeCategoryUpdate(before, update))) == 0 {
+			return ErrNothingToUpdate
+		}
+
+		if success, err = p.updater.UpdateCategory(ctx, id, version, update); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetCategoryById(ctx, uint64(id))
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationUpdate, EntityCategory, uint64(id), before, after)
+	})
+	if err != nil {
+		return false, p.categoryError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("category updated", slog.String("op", op), slog.Uint64("category_id", uint64(id)))
+
+	return success, nil
+}
+
+func (p *DomainPizzaLand) RemoveCategoryById(ctx context.Context, id uint32, version uint64) (success bool, err error) {
+	const op = "pizzaland.RemoveCategoryById"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetCategoryById(ctx, uint64(id))
+		if err != nil {
+			return err
+		}
+
+		if success, err = p.remover.RemoveCategoryById(ctx, uint64(id), version); err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRemove, EntityCategory, uint64(id), before, nil)
+	})
+	if err != nil {
+		return false, p.categoryError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("category moved to the trash", slog.String("op", op), slog.Uint64("category_id", uint64(id)))
+
+	return success, nil
+}
+
+func (p *DomainPizzaLand) RemoveCategoryByName(ctx context.Context, name string, version uint64) (success bool, err error) {
+	const op = "pizzaland.RemoveCategoryByName"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := p.getter.GetCategoryByName(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if success, err = p.remover.RemoveCategoryByName(ctx, name, version); err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRemove, EntityCategory, uint64(before.GetCategoryId().GetValue()), before, nil)
+	})
+	if err != nil {
+		return false, p.categoryError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("category moved to the trash", slog.String("op", op), slog.String("name", name))
+
+	return success, nil
+}
+
+func (p *DomainPizzaLand) RestoreCategory(ctx context.Context, id uint32) (success bool, err error) {
+	const op = "pizzaland.RestoreCategory"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		if success, err = p.updater.RestoreCategory(ctx, id); err != nil {
+			return err
+		}
+
+		after, err := p.getter.GetCategoryById(ctx, uint64(id))
+		if err != nil {
+			return err
+		}
+
+		return p.recordChange(ctx, OperationRestore, EntityCategory, uint64(id), nil, after)
+	})
+	if err != nil {
+		return false, p.categoryError(ctx, op, err)
+	}
+
+	p.logger(ctx).Info("category restored from the trash", slog.String("op", op), slog.Uint64("category_id", uint64(id)))
+
+	return success, nil
+}
+
+// Purge hard-deletes everything that stays in the trash longer than retention
+func (p *DomainPizzaLand) Purge(ctx context.Context, retention time.Duration) (pizzas, categories int64, err error) {
+	const op = "pizzaland.Purge"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { tracing.End(span, err) }()
+
+	pizzas, categories, err = p.remover.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		p.logger(ctx).Error("failed to purge the trash", slog.String("op", op), slog.String("error", err.Error()))
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizzas, categories, nil
+}
+
+// pizzaError translates storage errors of the pizza operations into the domain ones
+func (p *DomainPizzaLand) pizzaError(ctx context.Context, op string, err error) error {
+	if errors.Is(err, ErrNothingToUpdate) {
+		return fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+	}
+
+	if errors.Is(err, storage.ErrPizzaNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrPizzaNotFound)
+	}
+
+	if errors.Is(err, storage.ErrPizzaExists) {
+		return fmt.Errorf("%s: %w", op, ErrPizzaExists)
+	}
+
+	// the pizza is moved into or restored to a category which is not live
+	if errors.Is(err, storage.ErrCategoryNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+	}
+
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		return fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+	}
+
+	p.logger(ctx).Error("storage failure", slog.String("op", op), slog.String("error", err.Error()))
+
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// categoryError translates storage errors of the category operations into the domain ones
+func (p *DomainPizzaLand) categoryError(ctx context.Context, op string, err error) error {
+	if errors.Is(err, ErrNothingToUpdate) {
+		return fmt.Errorf("%s: %w", op, ErrNothingToUpdate)
+	}
+
+	if errors.Is(err, storage.ErrCategoryNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrCategoryNotFound)
+	}
+
+	if errors.Is(err, storage.ErrCategoryExists) {
+		return fmt.Errorf("%s: %w", op, ErrCategoryExists)
+	}
+
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		return fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+	}
+
+	p.logger(ctx).Error("storage failure", slog.String("op", op), slog.String("error", err.Error()))
+
+	return fmt.Errorf("%s: %w", op, err)
 }
 
-func (p *DomainPizzaLand) RemoveCategoryById(ctx context.Context, id uint32) (success bool, err error) {
-	panic("implement me")
+// logger returns the request-scoped logger of ctx, so the service logs carry the request id
+func (p *DomainPizzaLand) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, p.log)
 }
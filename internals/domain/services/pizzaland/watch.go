@@ -0,0 +1,204 @@
+package pizzaland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/lib/hub"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"google.golang.org/protobuf/proto"
+)
+
+// snapshotPageSize is the amount of pizzas and categories read at once for the watch snapshot
+const snapshotPageSize = 500
+
+var (
+	ErrWatchLagged = errors.New("watcher fell behind the menu changes")
+	ErrWatchClosed = errors.New("menu watch is closed")
+)
+
+// MenuChange is a committed change of a pizza or a category, Before and After are nil when it is not live
+type MenuChange struct {
+	Before proto.Message
+	After  proto.Message
+}
+
+// MenuHub fans the menu changes out to the watchers, they are published under the ids of their audit entries
+type MenuHub = hub.Hub[MenuChange]
+
+// kinds of the menu updates
+const (
+	// UpdateSnapshot is a live pizza or category of the snapshot
+	UpdateSnapshot = "snapshot"
+	// UpdateSnapshotEnd follows the snapshot, the watcher replaces its menu with the snapshot then
+	UpdateSnapshotEnd = "snapshot_end"
+	UpdateCreated     = "created"
+	UpdateUpdated     = "updated"
+	UpdateDeleted     = "deleted"
+)
+
+// MenuUpdate is a single message of the menu watch, at most one of Pizza and Category is set
+type MenuUpdate struct {
+	Seq      uint64
+	Kind     string
+	Pizza    *pizzalndv1.PizzaProperties
+	Category *pizzalndv1.CategoryProperties
+}
+
+// WatchMenu sends the snapshot of the live menu and then its changes until ctx is done or send fails.
+// Non-zero resumeAfter is the sequence number of the last update the watcher received, the changes after it
+// are sent instead of the snapshot while they are kept. Empty categories watch the whole menu,
+// a pizza moved out of the watched categories is sent as deleted and the one moved in as created.
+// The updates right after the snapshot may repeat the changes it already holds.
+func (p *DomainPizzaLand) WatchMenu(
+	ctx context.Context,
+	categories []uint32,
+	resumeAfter uint64,
+	send func(update MenuUpdate) error,
+) (err error) {
+	const op = "pizzaland.WatchMenu"
+
+	if p.menu == nil {
+		return fmt.Errorf("%s: %w", op, ErrWatchClosed)
+	}
+
+	sub, replay, last, ok := p.menu.Subscribe(resumeAfter)
+	defer sub.Close()
+
+	watched := make(map[uint32]bool, len(categories))
+	for _, id := range categories {
+		watched[id] = true
+	}
+	matches := func(m proto.Message) bool {
+		return m != nil && (len(watched) == 0 || watched[categoryOf(m)])
+	}
+
+	if resumeAfter == 0 || !ok {
+		// the snapshot holds the kept changes already
+		replay = nil
+		if err := p.sendSnapshot(ctx, last, matches, send); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, msg := range replay {
+		if err := sendChange(msg, matches, send); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-sub.Done():
+			if errors.Is(sub.Err(), hub.ErrSlow) {
+				return fmt.Errorf("%s: %w", op, ErrWatchLagged)
+			}
+
+			return fmt.Errorf("%s: %w", op, ErrWatchClosed)
+		case msg := <-sub.C():
+			if err := sendChange(msg, matches, send); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+}
+
+// sendSnapshot reads the live menu in a single transaction and sends it once the transaction is over,
+// so a slow watcher does not keep the database locked
+func (p *DomainPizzaLand) sendSnapshot(
+	ctx context.Context,
+	seq uint64,
+	matches func(m proto.Message) bool,
+	send func(update MenuUpdate) error,
+) (err error) {
+	ctx, span := tracer.Start(ctx, "pizzaland.sendSnapshot")
+	defer func() { tracing.End(span, err) }()
+
+	var snapshot []MenuUpdate
+	err = p.tx.InTx(ctx, func(ctx context.Context) error {
+		for offset := uint32(0); ; offset += snapshotPageSize {
+			page, err := p.getter.ListCategories(ctx, offset, snapshotPageSize)
+			if err != nil {
+				return err
+			}
+			for _, category := range page {
+				if matches(category) {
+					snapshot = append(snapshot, MenuUpdate{Seq: seq, Kind: UpdateSnapshot, Category: category})
+				}
+			}
+			if len(page) < snapshotPageSize {
+				break
+			}
+		}
+
+		for offset := uint32(0); ; offset += snapshotPageSize {
+			page, err := p.getter.List(ctx, offset, snapshotPageSize)
+			if err != nil {
+				return err
+			}
+			for _, pizza := range page {
+				if matches(pizza) {
+					snapshot = append(snapshot, MenuUpdate{Seq: seq, Kind: UpdateSnapshot, Pizza: pizza})
+				}
+			}
+			if len(page) < snapshotPageSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, update := range snapshot {
+		if err := send(update); err != nil {
+			return err
+		}
+	}
+
+	return send(MenuUpdate{Seq: seq, Kind: UpdateSnapshotEnd})
+}
+
+// sendChange sends the change as the watcher sees it, the changes outside the watched categories are skipped
+func sendChange(msg hub.Message[MenuChange], matches func(m proto.Message) bool, send func(update MenuUpdate) error) error {
+	change := msg.Value
+	before, after := matches(change.Before), matches(change.After)
+
+	update := MenuUpdate{Seq: msg.Seq}
+	entity := change.After
+	switch {
+	case before && after:
+		update.Kind = UpdateUpdated
+	case after:
+		update.Kind = UpdateCreated
+	case before:
+		update.Kind, entity = UpdateDeleted, change.Before
+	default:
+		return nil
+	}
+
+	switch m := entity.(type) {
+	case *pizzalndv1.PizzaProperties:
+		update.Pizza = m
+	case *pizzalndv1.CategoryProperties:
+		update.Category = m
+	}
+
+	return send(update)
+}
+
+// categoryOf returns the category of the pizza or the id of the category
+func categoryOf(m proto.Message) uint32 {
+	switch m := m.(type) {
+	case *pizzalndv1.PizzaProperties:
+		return m.GetCategoryId()
+	case *pizzalndv1.CategoryProperties:
+		return m.GetCategoryId().GetValue()
+	default:
+		return 0
+	}
+}
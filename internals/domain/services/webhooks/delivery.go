@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+type DeliveryStore interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Webhook(ctx context.Context, id uint64) (hook models.Webhook, err error)
+	DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []models.WebhookDelivery, err error)
+	CompleteWebhookDelivery(ctx context.Context, id uint64, status string, responseCode int, lastError string, at time.Time) error
+	RetryWebhookDelivery(ctx context.Context, id uint64, responseCode int, lastError string, nextAttemptAt time.Time) error
+	PostponeWebhookDeliveries(ctx context.Context, webhookId uint64, until time.Time) error
+	ResetWebhookFailures(ctx context.Context, id uint64) error
+	FailWebhook(ctx context.Context, id uint64) (failures int, err error)
+	DisableWebhook(ctx context.Context, id uint64, at time.Time) error
+}
+
+// Sender posts the delivery signed with the secret, code is the status of the response and zero when there was none
+type Sender interface {
+	Send(ctx context.Context, url, secret string, deliveryId uint64, payload []byte) (code int, err error)
+}
+
+// Options control the delivery attempts
+type Options struct {
+	// BatchSize is the amount of due deliveries read at once
+	BatchSize int
+	// MaxAttempts is how many times a delivery is tried before it fails
+	MaxAttempts int
+	// DisableAfter is how many failed attempts in a row disable the webhook
+	DisableAfter int
+	// MinBackoff is the wait after the first failure of the webhook, it doubles with every next one up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Deliverer calls the webhooks with their queued deliveries at least once.
+// The webhooks are called concurrently, the deliveries of one webhook in the order they were queued.
+type Deliverer struct {
+	log    *slog.Logger
+	store  DeliveryStore
+	sender Sender
+	opts   Options
+}
+
+func NewDeliverer(log *slog.Logger, store DeliveryStore, sender Sender, opts Options) *Deliverer {
+	return &Deliverer{
+		log:    log,
+		store:  store,
+		sender: sender,
+		opts:   opts,
+	}
+}
+
+// Dispatch sends the next batch of the due deliveries. A failed attempt holds back the later deliveries of its webhook
+// until the retry. more reports whether the batch was full.
+func (d *Deliverer) Dispatch(ctx context.Context) (delivered int, more bool, err error) {
+	const op = "webhooks.Dispatch"
+
+	due, err := d.store.DueWebhookDeliveries(ctx, time.Now(), d.opts.BatchSize)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var order []uint64
+	byWebhook := make(map[uint64][]models.WebhookDelivery)
+	for _, delivery := range due {
+		if _, ok := byWebhook[delivery.WebhookId]; !ok {
+			order = append(order, delivery.WebhookId)
+		}
+		byWebhook[delivery.WebhookId] = append(byWebhook[delivery.WebhookId], delivery)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, webhookId := range order {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			n, err := d.deliver(ctx, webhookId, byWebhook[webhookId])
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			delivered += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return delivered, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivered, len(due) == d.opts.BatchSize, nil
+}
+
+// deliver sends the deliveries of the webhook in order until one of them fails
+func (d *Deliverer) deliver(ctx context.Context, webhookId uint64, deliveries []models.WebhookDelivery) (delivered int, err error) {
+	hook, err := d.store.Webhook(ctx, webhookId)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		code, sendErr := d.sender.Send(ctx, hook.URL, hook.Secret, delivery.ID, []byte(delivery.Payload))
+		if ctx.Err() != nil {
+			// stopped, the abandoned attempt is not the fault of the webhook
+			return delivered, nil
+		}
+
+		if sendErr != nil {
+			return delivered, d.fail(ctx, hook, delivery, code, sendErr)
+		}
+
+		err := d.store.InTx(ctx, func(ctx context.Context) error {
+			if err := d.store.CompleteWebhookDelivery(ctx, delivery.ID, models.DeliveryDelivered, code, "", time.Now()); err != nil {
+				return err
+			}
+
+			return d.store.ResetWebhookFailures(ctx, hook.ID)
+		})
+		if err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// fail records the failed attempt, the delivery fails after its last attempt and the webhook is disabled
+// after DisableAfter failures in a row
+func (d *Deliverer) fail(ctx context.Context, hook models.Webhook, delivery models.WebhookDelivery, code int, sendErr error) error {
+	const op = "webhooks.fail"
+
+	log := d.log.With(
+		slog.String("op", op),
+		slog.Uint64("webhook_id", hook.ID),
+		slog.Uint64("delivery_id", delivery.ID),
+		slog.String("event_id", delivery.EventId),
+		slog.Int("attempt", delivery.Attempts+1),
+		slog.String("error", sendErr.Error()),
+	)
+
+	now := time.Now()
+
+	return d.store.InTx(ctx, func(ctx context.Context) error {
+		failures, err := d.store.FailWebhook(ctx, hook.ID)
+		if err != nil {
+			return err
+		}
+		retryAt := now.Add(d.backoff(failures - 1))
+
+		if delivery.Attempts+1 >= d.opts.MaxAttempts {
+			log.Error("webhook delivery failed")
+			err = d.store.CompleteWebhookDelivery(ctx, delivery.ID, models.DeliveryFailed, code, sendErr.Error(), now)
+		} else {
+			log.Warn("webhook delivery attempt failed", slog.Time("retry_at", retryAt))
+			err = d.store.RetryWebhookDelivery(ctx, delivery.ID, code, sendErr.Error(), retryAt)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := d.store.PostponeWebhookDeliveries(ctx, hook.ID, retryAt); err != nil {
+			return err
+		}
+
+		if failures >= d.opts.DisableAfter {
+			log.Error("webhook disabled after failures in a row", slog.Int("failures", failures))
+			return d.store.DisableWebhook(ctx, hook.ID, now)
+		}
+
+		return nil
+	})
+}
+
+// backoff is the wait after the failed attempt following the given number of the failed ones
+func (d *Deliverer) backoff(failed int) time.Duration {
+	wait := d.opts.MinBackoff
+	for i := 0; i < failed && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	return min(wait, d.opts.MaxBackoff)
+}
@@ -0,0 +1,297 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	webhooksClient "github.com/nhassl3/pizzaland/internals/clients/webhooks"
+	"github.com/nhassl3/pizzaland/internals/clients/webhooks/webhookstest"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/webhooks"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+)
+
+const eventType = "pizzaland.pizza.created"
+
+// fixture is the storage with the webhook of the receiver and the deliverer calling it
+type fixture struct {
+	storage   *sqlite.Storage
+	webhooks  *webhooks.Webhooks
+	deliverer *webhooks.Deliverer
+	receiver  *webhookstest.Receiver
+	hook      models.Webhook
+}
+
+func newFixture(t *testing.T, opts webhooks.Options, secret string) *fixture {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f := &fixture{
+		storage:  sqlitetest.New(t),
+		receiver: webhookstest.NewReceiver("whsec_receiver"),
+	}
+	t.Cleanup(f.receiver.Close)
+
+	client := webhooksClient.New(&http.Client{Timeout: 2 * time.Second})
+	t.Cleanup(client.Close)
+
+	f.webhooks = webhooks.New(log, f.storage)
+	f.deliverer = webhooks.NewDeliverer(log, f.storage, client, opts)
+
+	var err error
+	if f.hook, _, err = f.webhooks.Create(context.Background(), f.receiver.URL, []string{eventType}, secret); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+// queue saves the deliveries of the new events and returns their ids in order
+func (f *fixture) queue(t *testing.T, n int) []string {
+	t.Helper()
+
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+		event := models.Event{
+			SpecVersion: models.EventSpecVersion,
+			ID:          ids[i],
+			Source:      "/pizzaland",
+			Type:        eventType,
+			Time:        time.Now(),
+			Data:        []byte(`{"after":{"name":"Margherita"}}`),
+		}
+		if err := f.storage.SaveWebhookDeliveries(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return ids
+}
+
+func (f *fixture) dispatch(t *testing.T) int {
+	t.Helper()
+
+	delivered, _, err := f.deliverer.Dispatch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return delivered
+}
+
+// deliveries returns the deliveries of the webhook in the order they were queued
+func (f *fixture) deliveries(t *testing.T) []models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := f.webhooks.ListDeliveries(context.Background(), f.hook.ID, "", 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, j := 0, len(deliveries)-1; i < j; i, j = i+1, j-1 {
+		deliveries[i], deliveries[j] = deliveries[j], deliveries[i]
+	}
+
+	return deliveries
+}
+
+func (f *fixture) received() []string {
+	var ids []string
+	for _, delivery := range f.receiver.Deliveries() {
+		ids = append(ids, delivery.Event.ID)
+	}
+
+	return ids
+}
+
+var options = webhooks.Options{
+	BatchSize:    10,
+	MaxAttempts:  5,
+	DisableAfter: 10,
+	MinBackoff:   100 * time.Millisecond,
+	MaxBackoff:   time.Second,
+}
+
+func TestDispatchSigned(t *testing.T) {
+	f := newFixture(t, options, "whsec_receiver")
+	ids := f.queue(t, 3)
+
+	if delivered := f.dispatch(t); delivered != 3 {
+		t.Fatalf("%d delivered, want 3", delivered)
+	}
+	if rejected := f.receiver.Rejected(); rejected != 0 {
+		t.Fatalf("%d deliveries failed the signature check", rejected)
+	}
+
+	assertIDs(t, f.received(), ids)
+
+	queued := f.deliveries(t)
+	for i, delivery := range f.receiver.Deliveries() {
+		if delivery.ID != formatID(queued[i].ID) {
+			t.Errorf("delivery header %q, want %d", delivery.ID, queued[i].ID)
+		}
+		if queued[i].Status != models.DeliveryDelivered || queued[i].ResponseCode != http.StatusNoContent {
+			t.Errorf("delivery %d is %s with %d, want delivered with 204", queued[i].ID, queued[i].Status, queued[i].ResponseCode)
+		}
+	}
+
+	if delivered := f.dispatch(t); delivered != 0 {
+		t.Errorf("%d delivered again", delivered)
+	}
+}
+
+func TestDispatchWrongSecret(t *testing.T) {
+	f := newFixture(t, options, "whsec_other")
+	f.queue(t, 1)
+
+	if delivered := f.dispatch(t); delivered != 0 {
+		t.Fatalf("%d delivered with the wrong secret", delivered)
+	}
+	if rejected := f.receiver.Rejected(); rejected != 1 {
+		t.Fatalf("%d deliveries failed the signature check, want 1", rejected)
+	}
+
+	delivery := f.deliveries(t)[0]
+	if delivery.Status != models.DeliveryPending || delivery.ResponseCode != http.StatusUnauthorized || delivery.Attempts != 1 {
+		t.Errorf("delivery is %s with %d after %d attempts, want pending with 401 after 1", delivery.Status, delivery.ResponseCode, delivery.Attempts)
+	}
+}
+
+func TestDispatchRetriesInOrder(t *testing.T) {
+	f := newFixture(t, options, "whsec_receiver")
+	ids := f.queue(t, 3)
+
+	// the first delivery fails twice, the later ones wait for it
+	f.receiver.FailWith(http.StatusServiceUnavailable, http.StatusInternalServerError)
+
+	failedAt := time.Now()
+	if delivered := f.dispatch(t); delivered != 0 {
+		t.Fatalf("%d delivered after the failed head, want 0", delivered)
+	}
+	assertBackoff(t, f.deliveries(t), failedAt, options.MinBackoff)
+
+	// nothing is due before the backoff
+	if delivered := f.dispatch(t); delivered != 0 || len(f.receiver.Deliveries()) != 0 {
+		t.Fatalf("%d delivered before the backoff", delivered)
+	}
+
+	time.Sleep(options.MinBackoff)
+
+	failedAt = time.Now()
+	if delivered := f.dispatch(t); delivered != 0 {
+		t.Fatalf("%d delivered after the second failure, want 0", delivered)
+	}
+	// the wait doubles with the failures in a row
+	assertBackoff(t, f.deliveries(t), failedAt, 2*options.MinBackoff)
+
+	time.Sleep(2 * options.MinBackoff)
+
+	if delivered := f.dispatch(t); delivered != 3 {
+		t.Fatalf("%d delivered after the backoff, want 3", delivered)
+	}
+	assertIDs(t, f.received(), ids)
+
+	if head := f.deliveries(t)[0]; head.Attempts != 3 || head.Status != models.DeliveryDelivered {
+		t.Errorf("the head is %s after %d attempts, want delivered after 3", head.Status, head.Attempts)
+	}
+
+	hook, err := f.webhooks.Get(context.Background(), f.hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.ConsecutiveFailures != 0 {
+		t.Errorf("%d failures in a row after the delivery, want 0", hook.ConsecutiveFailures)
+	}
+}
+
+func TestDispatchDisablesWebhook(t *testing.T) {
+	opts := options
+	opts.DisableAfter = 3
+	opts.MinBackoff, opts.MaxBackoff = 10*time.Millisecond, 10*time.Millisecond
+
+	f := newFixture(t, opts, "whsec_receiver")
+	ids := f.queue(t, 2)
+	f.receiver.FailWith(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	for range opts.DisableAfter {
+		if delivered := f.dispatch(t); delivered != 0 {
+			t.Fatalf("%d delivered while failing", delivered)
+		}
+		time.Sleep(opts.MaxBackoff)
+	}
+
+	hook, err := f.webhooks.Get(context.Background(), f.hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.Enabled || hook.DisabledAt.IsZero() || hook.ConsecutiveFailures != opts.DisableAfter {
+		t.Fatalf("webhook enabled %t with %d failures in a row, want disabled after %d", hook.Enabled, hook.ConsecutiveFailures, opts.DisableAfter)
+	}
+
+	// the receiver works again, but the disabled webhook is not called
+	if delivered := f.dispatch(t); delivered != 0 {
+		t.Fatalf("%d delivered to the disabled webhook", delivered)
+	}
+	if head := f.deliveries(t)[0]; head.Status != models.DeliveryPending || head.Attempts != opts.DisableAfter {
+		t.Errorf("the head is %s after %d attempts, want pending after %d", head.Status, head.Attempts, opts.DisableAfter)
+	}
+
+	enabled := true
+	if _, err := f.webhooks.Update(context.Background(), f.hook.ID, models.WebhookUpdate{Enabled: &enabled}); err != nil {
+		t.Fatal(err)
+	}
+
+	if delivered := f.dispatch(t); delivered != 2 {
+		t.Fatalf("%d delivered after enabling the webhook, want 2", delivered)
+	}
+	assertIDs(t, f.received(), ids)
+}
+
+func assertIDs(t *testing.T, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("received %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("received %q, want %q in this order", got, want)
+		}
+	}
+}
+
+// assertBackoff checks the failed head waits for the backoff and the later deliveries are not tried before it
+func assertBackoff(t *testing.T, deliveries []models.WebhookDelivery, failedAt time.Time, backoff time.Duration) {
+	t.Helper()
+
+	for i, delivery := range deliveries {
+		if delivery.Status != models.DeliveryPending {
+			t.Fatalf("delivery %d is %s, want pending", i, delivery.Status)
+		}
+		if wait := delivery.NextAttemptAt.Sub(failedAt); wait < backoff-time.Millisecond || wait > backoff+time.Second {
+			t.Errorf("delivery %d is due %s after the failure, want %s", i, wait, backoff)
+		}
+	}
+
+	if head := deliveries[0]; head.ResponseCode == 0 || head.LastError == "" {
+		t.Errorf("the failure of the head is not recorded: %+v", head)
+	}
+	for _, delivery := range deliveries[1:] {
+		if delivery.Attempts != 0 {
+			t.Errorf("delivery %d was tried before the head", delivery.ID)
+		}
+	}
+}
+
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/storage"
+)
+
+const (
+	// secretPrefix starts every generated secret, so the leaked ones are easy to find by the secret scanners
+	secretPrefix = "whsec_"
+	secretLen    = 32
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+type Store interface {
+	SaveWebhook(ctx context.Context, hook models.Webhook) (id uint64, err error)
+	Webhook(ctx context.Context, id uint64) (hook models.Webhook, err error)
+	ListWebhooks(ctx context.Context, offset uint32, limit uint32) (hooks []models.Webhook, err error)
+	UpdateWebhook(ctx context.Context, id uint64, update models.WebhookUpdate, at time.Time) (hook models.Webhook, err error)
+	DeleteWebhook(ctx context.Context, id uint64) error
+	ListWebhookDeliveries(
+		ctx context.Context,
+		webhookId uint64,
+		status string,
+		offset uint32,
+		limit uint32,
+	) (deliveries []models.WebhookDelivery, err error)
+}
+
+// Webhooks manages the webhooks of the partners and their delivery log
+type Webhooks struct {
+	log   *slog.Logger
+	store Store
+}
+
+func New(log *slog.Logger, store Store) *Webhooks {
+	return &Webhooks{
+		log:   log,
+		store: store,
+	}
+}
+
+// Create stores the enabled webhook and returns it together with its secret, which is generated when empty
+func (w *Webhooks) Create(ctx context.Context, url string, eventTypes []string, secret string) (models.Webhook, string, error) {
+	const op = "webhooks.Create"
+
+	log := logger.FromContext(ctx, w.log).With(slog.String("op", op))
+
+	if secret == "" {
+		b := make([]byte, secretLen)
+		if _, err := rand.Read(b); err != nil {
+			return models.Webhook{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		secret = secretPrefix + base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	now := time.Now().UTC()
+	hook := models.Webhook{
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Enabled:    true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	var err error
+	if hook.ID, err = w.store.SaveWebhook(ctx, hook); err != nil {
+		log.Error("failed to save webhook", slog.String("error", err.Error()))
+		return models.Webhook{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook created", slog.Uint64("webhook_id", hook.ID), slog.String("url", url))
+
+	return hook, secret, nil
+}
+
+func (w *Webhooks) Get(ctx context.Context, id uint64) (models.Webhook, error) {
+	const op = "webhooks.Get"
+
+	hook, err := w.store.Webhook(ctx, id)
+	if err != nil {
+		return models.Webhook{}, w.storageError(ctx, op, "failed to get webhook", err)
+	}
+
+	return hook, nil
+}
+
+func (w *Webhooks) List(ctx context.Context, offset, limit uint32) ([]models.Webhook, error) {
+	const op = "webhooks.List"
+
+	hooks, err := w.store.ListWebhooks(ctx, offset, limit)
+	if err != nil {
+		return nil, w.storageError(ctx, op, "failed to list webhooks", err)
+	}
+
+	return hooks, nil
+}
+
+// Update changes the webhook, enabling the disabled one resets its failures and retries its pending deliveries
+func (w *Webhooks) Update(ctx context.Context, id uint64, update models.WebhookUpdate) (models.Webhook, error) {
+	const op = "webhooks.Update"
+
+	hook, err := w.store.UpdateWebhook(ctx, id, update, time.Now())
+	if err != nil {
+		return models.Webhook{}, w.storageError(ctx, op, "failed to update webhook", err)
+	}
+
+	logger.FromContext(ctx, w.log).Info(
+		"webhook updated",
+		slog.String("op", op), slog.Uint64("webhook_id", id), slog.Bool("enabled", hook.Enabled),
+	)
+
+	return hook, nil
+}
+
+// Delete removes the webhook and its delivery log, the pending deliveries are dropped
+func (w *Webhooks) Delete(ctx context.Context, id uint64) error {
+	const op = "webhooks.Delete"
+
+	if err := w.store.DeleteWebhook(ctx, id); err != nil {
+		return w.storageError(ctx, op, "failed to delete webhook", err)
+	}
+
+	logger.FromContext(ctx, w.log).Info("webhook deleted", slog.String("op", op), slog.Uint64("webhook_id", id))
+
+	return nil
+}
+
+// ListDeliveries returns the deliveries of the webhook newest first, all the statuses when status is empty
+func (w *Webhooks) ListDeliveries(ctx context.Context, webhookId uint64, status string, offset, limit uint32) ([]models.WebhookDelivery, error) {
+	const op = "webhooks.ListDeliveries"
+
+	if _, err := w.store.Webhook(ctx, webhookId); err != nil {
+		return nil, w.storageError(ctx, op, "failed to get webhook", err)
+	}
+
+	deliveries, err := w.store.ListWebhookDeliveries(ctx, webhookId, status, offset, limit)
+	if err != nil {
+		return nil, w.storageError(ctx, op, "failed to list webhook deliveries", err)
+	}
+
+	return deliveries, nil
+}
+
+// storageError maps the storage error to the domain one, logging the unexpected ones with msg
+func (w *Webhooks) storageError(ctx context.Context, op, msg string, err error) error {
+	if errors.Is(err, storage.ErrWebhookNotFound) {
+		return fmt.Errorf("%s: %w", op, ErrWebhookNotFound)
+	}
+
+	logger.FromContext(ctx, w.log).Error(msg, slog.String("op", op), slog.String("error", err.Error()))
+
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,176 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"strings"
+
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/lib/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const AuthorizationHeader = "authorization"
+
+// TokenVerifier returns the owner of the access token, the rejected tokens fail with sso.ErrInvalidToken
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (models.Identity, error)
+}
+
+// KeyVerifier returns the identity of the API key, the rejected keys fail with apikeys.ErrInvalidKey.
+// Cached returns the identity of the key verified recently without the cost of Verify.
+type KeyVerifier interface {
+	Verify(ctx context.Context, key string) (models.Identity, error)
+	Cached(key string) (models.Identity, bool)
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the authenticated caller
+func IdentityFromContext(ctx context.Context) (models.Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(models.Identity)
+
+	return identity, ok
+}
+
+// keyFailures is the budget of the invalid API keys of a peer
+var keyFailures = ratelimit.Budget{Rate: 1, Burst: 10}
+
+// APIKeyHeader carries the API key of the integrations
+const APIKeyHeader = "x-api-key"
+
+// anonymousActor is the caller recorded when the calls are not authenticated
+const anonymousActor = "anonymous"
+
+// Caller returns the subject of the authenticated caller and the id of the request, see pizzaland.CallerFunc
+func Caller(ctx context.Context) (actor, requestId string) {
+	actor = anonymousActor
+	if identity, ok := IdentityFromContext(ctx); ok {
+		actor = identity.Subject
+	}
+
+	return actor, RequestIdFromContext(ctx)
+}
+
+// Auth authenticates the calls by the "x-api-key" or the "authorization: Bearer <token>" metadata
+// and puts the caller into the context. Either verifier may be nil to turn its credentials off.
+// The health and the reflection services stay open, so the probes and the tools keep working.
+// The peers which sent too many invalid API keys fail with codes.ResourceExhausted before their keys are hashed,
+// the callers behind the trustedProxies are told apart by the x-forwarded-for metadata as by RateLimit.
+// The keys verified recently are accepted even from the throttled peers.
+func Auth(log *slog.Logger, tokens TokenVerifier, keys KeyVerifier, trustedProxies []netip.Prefix) Interceptor {
+	failures := ratelimit.New()
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := authenticate(ctx, log, tokens, keys, failures, trustedProxies, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := authenticate(ss.Context(), log, tokens, keys, failures, trustedProxies, info.FullMethod)
+			if err != nil {
+				return err
+			}
+
+			return handler(srv, withContext(ss, ctx))
+		},
+	}
+}
+
+func authenticate(
+	ctx context.Context,
+	log *slog.Logger,
+	tokens TokenVerifier,
+	keys KeyVerifier,
+	failures *ratelimit.Limiter,
+	trustedProxies []netip.Prefix,
+	method string,
+) (context.Context, error) {
+	const op = "interceptors.authenticate"
+
+	if isPublic(method) {
+		return ctx, nil
+	}
+
+	reqLog := logger.FromContext(ctx, log)
+
+	var (
+		identity models.Identity
+		err      error
+	)
+	if key, ok := apiKey(ctx); ok && keys != nil {
+		if cached, ok := keys.Cached(key); ok {
+			identity = cached
+		} else {
+			// every key checked costs an argon2id hash, so the peer pays a token which only the rejected keys keep
+			allowed, retryAfter, refund := failures.Take(ClientKey(ctx, trustedProxies), keyFailures)
+			if !allowed {
+				reqLog.Warn("too many invalid api keys", slog.String("op", op))
+				return nil, exhausted("too many invalid credentials", retryAfter)
+			}
+
+			if identity, err = keys.Verify(ctx, key); !errors.Is(err, apikeys.ErrInvalidKey) {
+				refund()
+			}
+		}
+	} else if token, ok := bearerToken(ctx); ok && tokens != nil {
+		identity, err = tokens.Verify(ctx, token)
+	} else {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	switch {
+	case errors.Is(err, sso.ErrInvalidToken), errors.Is(err, apikeys.ErrInvalidKey):
+		reqLog.Warn("credentials rejected", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	case err != nil:
+		reqLog.Error("failed to verify credentials", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, status.Error(codes.Unavailable, "authentication is unavailable")
+	}
+
+	ctx = logger.WithContext(ctx, reqLog.With(slog.String("subject", identity.Subject)))
+
+	return context.WithValue(ctx, identityKey{}, identity), nil
+}
+
+func apiKey(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, APIKeyHeader)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+
+	return values[0], true
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	values := metadata.ValueFromIncomingContext(ctx, AuthorizationHeader)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+// isPublic reports whether the method is served without authentication
+func isPublic(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.Health/") || strings.HasPrefix(method, "/grpc.reflection.")
+}
@@ -0,0 +1,232 @@
+package interceptors_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso"
+	"github.com/nhassl3/pizzaland/internals/clients/grpc/sso/ssotest"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuth(t *testing.T) {
+	server, err := ssotest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	server.AddUser(ssotest.User{ID: "alice", Email: "alice@example.com", Roles: []string{"editor"}})
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client, err := sso.New(log, server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	auth := interceptors.Auth(log, client, nil, nil)
+
+	expired := issue(t, server, "alice", -time.Hour)
+
+	// the token signed by the key the service no longer publishes
+	unpublished := issue(t, server, "alice", time.Hour)
+	if err := server.RotateKey(); err != nil {
+		t.Fatal(err)
+	}
+	valid := issue(t, server, "alice", time.Hour)
+
+	tests := []struct {
+		name          string
+		authorization []string
+		code          codes.Code
+	}{
+		{name: "valid token", authorization: []string{"Bearer " + valid}, code: codes.OK},
+		{name: "lower case scheme", authorization: []string{"bearer " + valid}, code: codes.OK},
+		{name: "expired token", authorization: []string{"Bearer " + expired}, code: codes.Unauthenticated},
+		{name: "unknown signing key", authorization: []string{"Bearer " + unpublished}, code: codes.Unauthenticated},
+		{name: "bad signature", authorization: []string{"Bearer " + valid[:len(valid)-4] + "AAAA"}, code: codes.Unauthenticated},
+		{name: "not a token", authorization: []string{"Bearer not.a.token"}, code: codes.Unauthenticated},
+		{name: "missing header", code: codes.Unauthenticated},
+		{name: "empty token", authorization: []string{"Bearer "}, code: codes.Unauthenticated},
+		{name: "other scheme", authorization: []string{"Basic " + valid}, code: codes.Unauthenticated},
+		{name: "no scheme", authorization: []string{valid}, code: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.authorization != nil {
+				md.Set(interceptors.AuthorizationHeader, tt.authorization...)
+			}
+
+			identity, err := call(auth, metadata.NewIncomingContext(context.Background(), md), pizzalndv1.PizzaLand_List_FullMethodName)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code %s, want %s: %v", code, tt.code, err)
+			}
+			if tt.code == codes.OK && identity.Subject != "alice" {
+				t.Errorf("identity %+v, want alice", identity)
+			}
+		})
+	}
+
+	t.Run("health stays open", func(t *testing.T) {
+		if _, err := call(auth, context.Background(), "/grpc.health.v1.Health/Check"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("unreachable service", func(t *testing.T) {
+		// the tokens are validated by the service, which fails every call
+		server.FailWith(status.Error(codes.Unavailable, "down"))
+		defer server.FailWith(nil)
+
+		cfg := server.Config()
+		cfg.LocalVerify = false
+		offline, err := sso.New(log, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer offline.Close()
+
+		md := metadata.Pairs(interceptors.AuthorizationHeader, "Bearer "+valid)
+		_, err = call(interceptors.Auth(log, offline, nil, nil), metadata.NewIncomingContext(context.Background(), md), pizzalndv1.PizzaLand_List_FullMethodName)
+		if code := status.Code(err); code != codes.Unavailable {
+			t.Fatalf("code %s, want Unavailable: %v", code, err)
+		}
+	})
+}
+
+// call runs the unary interceptor and returns the identity the handler saw
+func call(auth interceptors.Interceptor, ctx context.Context, method string) (models.Identity, error) {
+	var identity models.Identity
+
+	_, err := auth.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ any) (any, error) {
+		identity, _ = interceptors.IdentityFromContext(ctx)
+		return nil, nil
+	})
+
+	return identity, err
+}
+
+func issue(t *testing.T, server *ssotest.Server, userID string, ttl time.Duration) string {
+	t.Helper()
+
+	token, err := server.Issue(userID, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// keys accepts the valid keys and counts the verifications, the verified keys are cached
+type keys struct {
+	valid  map[string]bool
+	cached map[string]bool
+	calls  int
+}
+
+func newKeys(valid ...string) *keys {
+	k := &keys{valid: make(map[string]bool), cached: make(map[string]bool)}
+	for _, key := range valid {
+		k.valid[key] = true
+	}
+
+	return k
+}
+
+func (k *keys) Verify(_ context.Context, key string) (models.Identity, error) {
+	k.calls++
+	if !k.valid[key] {
+		return models.Identity{}, apikeys.ErrInvalidKey
+	}
+	k.cached[key] = true
+
+	return models.Identity{Subject: "apikey:1"}, nil
+}
+
+func (k *keys) Cached(key string) (models.Identity, bool) {
+	if !k.cached[key] {
+		return models.Identity{}, false
+	}
+
+	return models.Identity{Subject: "apikey:1"}, true
+}
+
+func TestAuthInvalidKeys(t *testing.T) {
+	verifier := newKeys("pl_valid", "pl_other", "pl_third")
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	auth := interceptors.Auth(slog.New(slog.NewTextHandler(io.Discard, nil)), nil, verifier, proxies)
+
+	// callWith calls from the addr, through the proxy when forwarded is set
+	callWith := func(key, addr, forwarded string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 40000}})
+		md := metadata.Pairs(interceptors.APIKeyHeader, key)
+		if forwarded != "" {
+			md.Set("x-forwarded-for", forwarded)
+		}
+
+		_, err := call(auth, metadata.NewIncomingContext(ctx, md), pizzalndv1.PizzaLand_List_FullMethodName)
+		return err
+	}
+
+	// the valid keys give their tokens back
+	for range 50 {
+		if err := callWith("pl_valid", "192.0.2.1", ""); err != nil {
+			t.Fatalf("valid key: %v", err)
+		}
+	}
+
+	for i := range 10 {
+		if code := status.Code(callWith("pl_guess", "192.0.2.1", "")); code != codes.Unauthenticated {
+			t.Fatalf("invalid key %d: code %s, want Unauthenticated", i, code)
+		}
+	}
+
+	calls := verifier.calls
+	for _, key := range []string{"pl_guess", "pl_other"} {
+		if code := status.Code(callWith(key, "192.0.2.1", "")); code != codes.ResourceExhausted {
+			t.Errorf("key %s after the failures: code %s, want ResourceExhausted", key, code)
+		}
+	}
+	if verifier.calls != calls {
+		t.Errorf("%d keys of the throttled peer verified, want none", verifier.calls-calls)
+	}
+
+	if err := callWith("pl_valid", "192.0.2.1", ""); err != nil {
+		t.Errorf("cached key of the throttled peer: %v", err)
+	}
+
+	if err := callWith("pl_other", "192.0.2.2", ""); err != nil {
+		t.Errorf("valid key of another peer: %v", err)
+	}
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		for i := range 10 {
+			if code := status.Code(callWith("pl_guess", "10.0.0.1", "203.0.113.1")); code != codes.Unauthenticated {
+				t.Fatalf("invalid key %d: code %s, want Unauthenticated", i, code)
+			}
+		}
+		if code := status.Code(callWith("pl_guess", "10.0.0.1", "203.0.113.1")); code != codes.ResourceExhausted {
+			t.Errorf("forwarded caller after the failures: code %s, want ResourceExhausted", code)
+		}
+
+		// the other callers of the proxy are not throttled with the forwarded one
+		if err := callWith("pl_third", "10.0.0.1", "203.0.113.2"); err != nil {
+			t.Errorf("another caller of the proxy: %v", err)
+		}
+	})
+}
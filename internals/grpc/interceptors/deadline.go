@@ -0,0 +1,56 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Deadline bounds every call by the configured timeout, the earlier client deadline still wins.
+// overrides are keyed by the full method name ("/package.Service/Method") or by the bare method name.
+// A non-positive timeout leaves the call without the server-side deadline.
+// The server and bidi streams like WatchMenu, the health watch and reflection last as long as the client wants,
+// so they get no default deadline, only their override.
+func Deadline(timeout time.Duration, overrides map[string]time.Duration) Interceptor {
+	timeoutOf := func(method string, fallback time.Duration) time.Duration {
+		if t, ok := overrides[method]; ok {
+			return t
+		}
+		if t, ok := overrides[path.Base(method)]; ok {
+			return t
+		}
+
+		return fallback
+	}
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, cancel := withTimeout(ctx, timeoutOf(info.FullMethod, timeout))
+			defer cancel()
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			fallback := timeout
+			if info.IsServerStream {
+				fallback = 0
+			}
+
+			ctx, cancel := withTimeout(ss.Context(), timeoutOf(info.FullMethod, fallback))
+			defer cancel()
+
+			return handler(srv, withContext(ss, ctx))
+		},
+	}
+}
+
+// withTimeout keeps the client deadline when it comes before the timeout
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,65 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	RequestIdName = "request_id"
+	LoggingName   = "logging"
+	RecoveryName  = "recovery"
+	TracingName   = "tracing"
+)
+
+// Interceptor is a named pair of the unary and stream server interceptors
+type Interceptor struct {
+	Unary  grpc.UnaryServerInterceptor
+	Stream grpc.StreamServerInterceptor
+}
+
+// Chain builds the interceptors listed in names, the first one is the outermost
+func Chain(log *slog.Logger, names []string) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	const op = "interceptors.Chain"
+
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	for _, name := range names {
+		var interceptor Interceptor
+		switch name {
+		case RequestIdName:
+			interceptor = RequestId()
+		case LoggingName:
+			interceptor = Logging(log)
+		case RecoveryName:
+			interceptor = Recovery(log)
+		case TracingName:
+			interceptor = Tracing()
+		default:
+			return nil, nil, fmt.Errorf("%s: unknown interceptor %q", op, name)
+		}
+
+		unary, stream = append(unary, interceptor.Unary), append(stream, interceptor.Stream)
+	}
+
+	return unary, stream, nil
+}
+
+// serverStream replaces the context of the wrapped stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
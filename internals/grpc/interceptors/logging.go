@@ -0,0 +1,78 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Logging puts the request-scoped logger into the context and logs every call once it is finished
+func Logging(log *slog.Logger) Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			reqLog := requestLogger(ctx, log, info.FullMethod)
+			start := time.Now()
+
+			resp, err := handler(logger.WithContext(ctx, reqLog), req)
+
+			logCall(ctx, reqLog, start, err)
+
+			return resp, err
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx := ss.Context()
+			reqLog := requestLogger(ctx, log, info.FullMethod)
+			start := time.Now()
+
+			err := handler(srv, withContext(ss, logger.WithContext(ctx, reqLog)))
+
+			logCall(ctx, reqLog, start, err)
+
+			return err
+		},
+	}
+}
+
+func requestLogger(ctx context.Context, log *slog.Logger, method string) *slog.Logger {
+	log = log.With(slog.String("method", method))
+	if id := RequestIdFromContext(ctx); id != "" {
+		log = log.With(slog.String("request_id", id))
+	}
+	// the tracing interceptor runs first when it is configured before the logging one
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		log = log.With(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+
+	return log
+}
+
+func logCall(ctx context.Context, log *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+
+	attrs := []any{
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	switch code {
+	case codes.OK:
+		log.Info("call finished", attrs...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		log.Error("call failed", attrs...)
+	default:
+		log.Warn("call failed", attrs...)
+	}
+}
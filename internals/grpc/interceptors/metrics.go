@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics counts the calls and observes their latency per method and status code.
+// The collectors are registered in reg.
+func Metrics(reg prometheus.Registerer) (Interceptor, error) {
+	const op = "interceptors.Metrics"
+
+	calls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pizzaland",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of the finished gRPC calls.",
+	}, []string{"method", "code"})
+
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "pizzaland",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the gRPC calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	for _, collector := range []prometheus.Collector{calls, durations} {
+		if err := reg.Register(collector); err != nil {
+			return Interceptor{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	observe := func(method string, start time.Time, err error) {
+		code := status.Code(err).String()
+
+		calls.WithLabelValues(method, code).Inc()
+		durations.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+	}
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			start := time.Now()
+
+			resp, err := handler(ctx, req)
+
+			observe(info.FullMethod, start, err)
+
+			return resp, err
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+
+			err := handler(srv, ss)
+
+			observe(info.FullMethod, start, err)
+
+			return err
+		},
+	}, nil
+}
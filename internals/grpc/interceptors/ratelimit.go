@@ -0,0 +1,169 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"path"
+	"strings"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/lib/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimits configures the RateLimit interceptor
+type RateLimits struct {
+	// Default is the budget of every client for every method
+	Default ratelimit.Budget
+	// Methods override Default, keyed by the full or the bare method name
+	Methods map[string]ratelimit.Budget
+	// MaxInFlightWrites caps the writes running at once over all clients, zero leaves them uncapped
+	MaxInFlightWrites int
+	// WriteRetryAfter is suggested to the writes rejected by the cap
+	WriteRetryAfter time.Duration
+	// TrustedProxies are the peers whose x-forwarded-for metadata is honoured, like the address the gateway dials from
+	TrustedProxies []netip.Prefix
+}
+
+// RateLimit rejects the calls of the clients which used up their budget of the method
+// and the writes over the in-flight cap with codes.ResourceExhausted and the RetryInfo details.
+// Every client has a token bucket per method, see ClientKey for how the clients are told apart.
+func RateLimit(limits RateLimits) Interceptor {
+	limiter := ratelimit.New()
+
+	var writes ratelimit.Semaphore
+	if limits.MaxInFlightWrites > 0 {
+		writes = ratelimit.NewSemaphore(limits.MaxInFlightWrites)
+	}
+
+	budgetOf := func(method string) ratelimit.Budget {
+		if budget, ok := limits.Methods[method]; ok {
+			return budget
+		}
+		if budget, ok := limits.Methods[path.Base(method)]; ok {
+			return budget
+		}
+
+		return limits.Default
+	}
+
+	// admit returns the release of the write slot, it must be called once the call is finished
+	admit := func(ctx context.Context, method string) (func(), error) {
+		if ok, retryAfter := limiter.Allow(ClientKey(ctx, limits.TrustedProxies)+" "+method, budgetOf(method)); !ok {
+			return nil, exhausted("rate limit of the method exceeded", retryAfter)
+		}
+
+		if writes == nil || !isWrite(method) {
+			return func() {}, nil
+		}
+		if !writes.TryAcquire() {
+			return nil, exhausted("too many writes in flight", limits.WriteRetryAfter)
+		}
+
+		return writes.Release, nil
+	}
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			release, err := admit(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			release, err := admit(ss.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			return handler(srv, ss)
+		},
+	}
+}
+
+// ClientKey identifies the authenticated caller by the subject and others by the peer address.
+// The calls of the trusted proxies are keyed by the client address they forward instead,
+// the credentials are not used before they are verified, so the made-up ones do not get buckets of their own.
+func ClientKey(ctx context.Context, trustedProxies []netip.Prefix) string {
+	if identity, ok := IdentityFromContext(ctx); ok {
+		return "sub:" + identity.Subject
+	}
+
+	var host string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host = p.Addr.String()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+
+	if isTrusted(host, trustedProxies) {
+		// the proxy appends the address it saw to the header, so only the last entry can be trusted
+		md, _ := metadata.FromIncomingContext(ctx)
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) != 0 {
+			entries := strings.Split(forwarded[len(forwarded)-1], ",")
+			host = strings.TrimSpace(entries[len(entries)-1])
+		}
+	}
+
+	return "peer:" + host
+}
+
+func isTrusted(host string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWrite reports whether the method of the PizzaLand service changes data,
+// its methods named Get*, List* and Watch* only read, like the health and the reflection services do
+func isWrite(method string) bool {
+	if !strings.HasPrefix(method, "/"+pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/") {
+		return false
+	}
+
+	name := path.Base(method)
+	for _, prefix := range []string{"Get", "List", "Watch"} {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func exhausted(msg string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if retryAfter <= 0 {
+		return st.Err()
+	}
+
+	// round up, so the client retrying right on time finds the token
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter.Round(time.Millisecond) + time.Millisecond)})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,247 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"github.com/nhassl3/pizzaland/internals/lib/rbac"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// PolicySource returns the RBAC policy in force
+type PolicySource interface {
+	Policy() *rbac.Policy
+}
+
+// CategoryResolver finds the categories of the pizzas and of the category names the requests refer to
+type CategoryResolver interface {
+	PizzaCategory(ctx context.Context, id uint64, name string) (uint32, error)
+	CategoryId(ctx context.Context, name string) (uint32, error)
+}
+
+// RBAC lets the authenticated callers call only the PizzaLand methods their roles allow.
+// When the roles limit the method to some categories, every pizza and category the request
+// refers to must belong to them and the requests referring to none are denied, as are the client streams.
+func RBAC(log *slog.Logger, policies PolicySource, resolver CategoryResolver) Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			msg, _ := req.(proto.Message)
+			if err := authorize(ctx, log, policies, resolver, info.FullMethod, msg); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if !info.IsClientStream {
+				// the single request is read by the handler first, so it is authorized once received
+				return handler(srv, &authorizedStream{ServerStream: ss, authorize: func(req proto.Message) error {
+					return authorize(ss.Context(), log, policies, resolver, info.FullMethod, req)
+				}})
+			}
+
+			// the requests of the stream are not read yet, so the category limits deny the call
+			if err := authorize(ss.Context(), log, policies, resolver, info.FullMethod, nil); err != nil {
+				return err
+			}
+
+			return handler(srv, ss)
+		},
+	}
+}
+
+// authorizedStream authorizes the request of the server streaming call when it is received
+type authorizedStream struct {
+	grpc.ServerStream
+	authorize  func(req proto.Message) error
+	authorized bool
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+
+	req, _ := m.(proto.Message)
+	if err := s.authorize(req); err != nil {
+		return err
+	}
+	s.authorized = true
+
+	return nil
+}
+
+func authorize(
+	ctx context.Context,
+	log *slog.Logger,
+	policies PolicySource,
+	resolver CategoryResolver,
+	method string,
+	req proto.Message,
+) error {
+	const op = "interceptors.authorize"
+
+	if !strings.HasPrefix(method, "/"+pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	reqLog := logger.FromContext(ctx, log).With(
+		slog.String("op", op),
+		slog.String("subject", identity.Subject),
+		slog.Any("roles", identity.Roles),
+	)
+	deny := func(reason string) error {
+		reqLog.Warn("permission denied", slog.String("reason", reason))
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+
+	allowed, categories := policies.Policy().Authorize(identity.Roles, method)
+	if !allowed {
+		return deny("no role allows the method")
+	}
+	if categories == nil {
+		return nil
+	}
+	if req == nil {
+		return deny("the roles limit the method to categories")
+	}
+
+	ids, err := requestCategories(ctx, resolver, req)
+	switch {
+	case errors.Is(err, pizzaland.ErrPizzaNotFound), errors.Is(err, pizzaland.ErrCategoryNotFound), errors.Is(err, errCategoryId):
+		return deny(err.Error())
+	case err != nil:
+		reqLog.Error("failed to resolve categories", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "failed to authorize")
+	case len(ids) == 0:
+		return deny("the request refers to no category")
+	}
+
+	for _, id := range ids {
+		if !categories[id] {
+			return deny("the roles do not allow the category")
+		}
+	}
+
+	return nil
+}
+
+var errCategoryId = errors.New("category id out of range")
+
+// categoryRefs are the pizzas and categories a request refers to
+type categoryRefs struct {
+	categoryIds   []uint32
+	categoryNames []string
+	pizzaIds      []uint64
+	pizzaNames    []string
+	err           error
+}
+
+// requestCategories returns the ids of the categories the request refers to, directly or through its pizzas
+func requestCategories(ctx context.Context, resolver CategoryResolver, req proto.Message) ([]uint32, error) {
+	var refs categoryRefs
+	refs.collect(req.ProtoReflect())
+	if refs.err != nil {
+		return nil, refs.err
+	}
+
+	ids := refs.categoryIds
+	for _, name := range refs.categoryNames {
+		id, err := resolver.CategoryId(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	for _, id := range refs.pizzaIds {
+		categoryId, err := resolver.PizzaCategory(ctx, id, "")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, categoryId)
+	}
+	for _, name := range refs.pizzaNames {
+		categoryId, err := resolver.PizzaCategory(ctx, 0, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, categoryId)
+	}
+
+	return ids, nil
+}
+
+// collect walks the set fields of the message and the nested ones, the wrappers count as their values
+func (r *categoryRefs) collect(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && r.err == nil; i++ {
+				if fd.Kind() == protoreflect.MessageKind {
+					r.collect(list.Get(i).Message())
+				} else {
+					r.add(fd.Name(), list.Get(i))
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind && isWrapper(fd.Message()):
+			wrapped := v.Message()
+			r.add(fd.Name(), wrapped.Get(fd.Message().Fields().ByName("value")))
+		case fd.Kind() == protoreflect.MessageKind:
+			r.collect(v.Message())
+		default:
+			r.add(fd.Name(), v)
+		}
+
+		return r.err == nil
+	})
+}
+
+func (r *categoryRefs) add(name protoreflect.Name, v protoreflect.Value) {
+	switch name {
+	case "category_id":
+		id := v.Uint()
+		if id > math.MaxUint32 {
+			r.err = errCategoryId
+			return
+		}
+		if id != 0 {
+			r.categoryIds = append(r.categoryIds, uint32(id))
+		}
+	case "category_name":
+		if name := v.String(); name != "" {
+			r.categoryNames = append(r.categoryNames, name)
+		}
+	case "pizza_id":
+		if id := v.Uint(); id != 0 {
+			r.pizzaIds = append(r.pizzaIds, id)
+		}
+	case "pizza_name":
+		if name := v.String(); name != "" {
+			r.pizzaNames = append(r.pizzaNames, name)
+		}
+	}
+}
+
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf" && strings.HasSuffix(string(md.Name()), "Value")
+}
@@ -0,0 +1,82 @@
+package interceptors_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	"github.com/nhassl3/pizzaland/internals/lib/rbac"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// roles gives every token the roles it names
+type roles struct{}
+
+func (roles) Verify(_ context.Context, token string) (models.Identity, error) {
+	return models.Identity{Subject: "alice", Roles: []string{token}}, nil
+}
+
+// policy is the policy file already loaded
+type policy struct{ policy *rbac.Policy }
+
+func (p policy) Policy() *rbac.Policy { return p.policy }
+
+// categories knows the Classic category with the id 1 and the Seasonal one with the id 2
+type categories struct{}
+
+func (categories) PizzaCategory(context.Context, uint64, string) (uint32, error) {
+	return 0, pizzaland.ErrPizzaNotFound
+}
+
+func (categories) CategoryId(_ context.Context, name string) (uint32, error) {
+	switch name {
+	case "Classic":
+		return 1, nil
+	case "Seasonal":
+		return 2, nil
+	}
+
+	return 0, pizzaland.ErrCategoryNotFound
+}
+
+func TestRBACListCategory(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	auth := interceptors.Auth(log, roles{}, nil, nil)
+	authz := interceptors.RBAC(log, policy{&rbac.Policy{Roles: map[string]rbac.Role{
+		"classic": {Methods: []string{"List"}, Categories: []uint32{1}},
+	}}}, categories{})
+
+	tests := []struct {
+		name string
+		req  *pizzalndv1.ListRequest
+		code codes.Code
+	}{
+		{name: "category id", req: &pizzalndv1.ListRequest{CategoryId: wrapperspb.UInt32(1)}, code: codes.OK},
+		{name: "category name", req: &pizzalndv1.ListRequest{CategoryName: wrapperspb.String("Classic")}, code: codes.OK},
+		{name: "other category id", req: &pizzalndv1.ListRequest{CategoryId: wrapperspb.UInt32(2)}, code: codes.PermissionDenied},
+		{name: "other category name", req: &pizzalndv1.ListRequest{CategoryName: wrapperspb.String("Seasonal")}, code: codes.PermissionDenied},
+		{name: "every category", req: &pizzalndv1.ListRequest{}, code: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(interceptors.AuthorizationHeader, "Bearer classic"))
+			info := &grpc.UnaryServerInfo{FullMethod: pizzalndv1.PizzaLand_List_FullMethodName}
+
+			_, err := auth.Unary(ctx, tt.req, info, func(ctx context.Context, req any) (any, error) {
+				return authz.Unary(ctx, req, info, func(context.Context, any) (any, error) { return nil, nil })
+			})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code %s, want %s: %v", code, tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/nhassl3/pizzaland/internals/lib/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recovery turns a handler panic into codes.Internal instead of crashing the server
+func Recovery(log *slog.Logger) Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = recovered(ctx, log, r)
+				}
+			}()
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = recovered(ss.Context(), log, r)
+				}
+			}()
+
+			return handler(srv, ss)
+		},
+	}
+}
+
+func recovered(ctx context.Context, log *slog.Logger, r any) error {
+	logger.FromContext(ctx, log).Error(
+		"handler panicked",
+		slog.String("panic", fmt.Sprint(r)),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	return status.Error(codes.Internal, "internal error")
+}
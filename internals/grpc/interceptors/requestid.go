@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	RequestIdHeader = "x-request-id"
+
+	maxRequestIdLen = 128
+)
+
+type requestIdKey struct{}
+
+// RequestIdFromContext returns the id of the request being served
+func RequestIdFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+
+	return id
+}
+
+// RequestId takes the x-request-id of the caller or generates a new one.
+// The id is put into the context and sent back in the response header.
+func RequestId() Interceptor {
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx = requestId(ctx)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIdHeader, RequestIdFromContext(ctx)))
+
+			return handler(ctx, req)
+		},
+		Stream: func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx := requestId(ss.Context())
+			_ = ss.SetHeader(metadata.Pairs(RequestIdHeader, RequestIdFromContext(ctx)))
+
+			return handler(srv, withContext(ss, ctx))
+		},
+	}
+}
+
+func requestId(ctx context.Context) context.Context {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, RequestIdHeader); len(values) != 0 && validRequestId(values[0]) {
+		id = values[0]
+	} else {
+		id = newRequestId()
+	}
+
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+// validRequestId accepts short printable ASCII ids, anything else could break the log lines
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestId() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
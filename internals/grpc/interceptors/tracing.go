@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+
+// Tracing starts the server span of every call, continuing the trace of the W3C traceparent metadata
+func Tracing() Interceptor {
+	tracer := otel.Tracer(tracerName)
+
+	return Interceptor{
+		Unary: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, span := startSpan(ctx, tracer, info.FullMethod)
+
+			resp, err := handler(ctx, req)
+
+			endSpan(span, err)
+
+			return resp, err
+		},
+		Stream: func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, span := startSpan(ss.Context(), tracer, info.FullMethod)
+
+			err := handler(srv, withContext(ss, ctx))
+
+			endSpan(span, err)
+
+			return err
+		},
+	}
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+
+	return tracer.Start(ctx, service+"/"+name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", name),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	span.SetAttributes(attribute.Int64("rpc.grpc.status_code", int64(status.Code(err))))
+
+	tracing.End(span, err)
+}
+
+// metadataCarrier reads the propagated trace context from the incoming metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) != 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
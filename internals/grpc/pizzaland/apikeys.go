@@ -0,0 +1,88 @@
+package pizzaland
+
+import (
+	"context"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKeys manages the API keys of the machine clients
+type APIKeys interface {
+	Create(ctx context.Context, label string, scopes []string, expiresAt time.Time) (key models.APIKey, secret string, err error)
+	List(ctx context.Context, offset, limit uint32) (keys []models.APIKey, err error)
+	Revoke(ctx context.Context, id uint64) error
+}
+
+func (api *ServerAPI) CreateAPIKey(ctx context.Context, in *pizzalndv1.CreateAPIKeyRequest) (*pizzalndv1.CreateAPIKeyResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var expiresAt time.Time
+	if in.GetExpiresAt() != nil {
+		expiresAt = in.GetExpiresAt().AsTime()
+	}
+
+	key, secret, err := api.apiKeys.Create(ctx, in.GetLabel(), in.GetScopes(), expiresAt)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.CreateAPIKeyResponse{Key: apiKeyToProto(key), Secret: secret}, nil
+}
+
+func (api *ServerAPI) ListAPIKeys(ctx context.Context, in *pizzalndv1.ListAPIKeysRequest) (*pizzalndv1.ListAPIKeysResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	keys, err := api.apiKeys.List(ctx, in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	out := &pizzalndv1.ListAPIKeysResponse{Key: make([]*pizzalndv1.APIKey, 0, len(keys))}
+	for _, key := range keys {
+		out.Key = append(out.Key, apiKeyToProto(key))
+	}
+
+	return out, nil
+}
+
+func (api *ServerAPI) RevokeAPIKey(ctx context.Context, in *pizzalndv1.RevokeAPIKeyRequest) (*pizzalndv1.RevokeAPIKeyResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := api.apiKeys.Revoke(ctx, in.GetKeyId()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.RevokeAPIKeyResponse{Success: true}, nil
+}
+
+func apiKeyToProto(key models.APIKey) *pizzalndv1.APIKey {
+	out := &pizzalndv1.APIKey{
+		KeyId:     key.ID,
+		Prefix:    key.Prefix,
+		Label:     key.Label,
+		Scopes:    key.Scopes,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if !key.ExpiresAt.IsZero() {
+		out.ExpiresAt = timestamppb.New(key.ExpiresAt)
+	}
+	if !key.LastUsedAt.IsZero() {
+		out.LastUsedAt = timestamppb.New(key.LastUsedAt)
+	}
+	if !key.RevokedAt.IsZero() {
+		out.RevokedAt = timestamppb.New(key.RevokedAt)
+	}
+
+	return out
+}
@@ -0,0 +1,50 @@
+package pizzaland
+
+import (
+	"context"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *ServerAPI) ListAuditEvents(ctx context.Context, in *pizzalndv1.ListAuditEventsRequest) (*pizzalndv1.ListAuditEventsResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := models.AuditFilter{Entity: in.GetEntity(), Actor: in.GetActor()}
+	if in.GetFrom() != nil {
+		filter.From = in.GetFrom().AsTime()
+	}
+	if in.GetTo() != nil {
+		filter.To = in.GetTo().AsTime()
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from")
+	}
+
+	events, err := api.pizzaLand.ListAuditEvents(ctx, filter, in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	out := &pizzalndv1.ListAuditEventsResponse{Event: make([]*pizzalndv1.AuditEvent, 0, len(events))}
+	for _, event := range events {
+		out.Event = append(out.Event, &pizzalndv1.AuditEvent{
+			EventId:    event.ID,
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Actor:      event.Actor,
+			RequestId:  event.RequestId,
+			Operation:  event.Operation,
+			Entity:     event.Entity,
+			EntityId:   event.EntityId,
+			Before:     event.Before,
+			After:      event.After,
+		})
+	}
+
+	return out, nil
+}
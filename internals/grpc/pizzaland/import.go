@@ -0,0 +1,103 @@
+package pizzaland
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/menufile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportSize is the largest menu file accepted by ImportMenu
+const maxImportSize = 16 << 20
+
+var menuFileFormats = map[pizzalndv1.MenuFileFormat]string{
+	pizzalndv1.MenuFileFormat_MENU_FILE_CSV:  menufile.CSV,
+	pizzalndv1.MenuFileFormat_MENU_FILE_JSON: menufile.JSON,
+	pizzalndv1.MenuFileFormat_MENU_FILE_YAML: menufile.YAML,
+}
+
+func (api *ServerAPI) ImportMenu(stream grpc.ClientStreamingServer[pizzalndv1.ImportMenuRequest, pizzalndv1.ImportMenuResponse]) error {
+	var (
+		options *pizzalndv1.ImportMenuOptions
+		file    bytes.Buffer
+	)
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := in.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		switch part := in.GetPart().(type) {
+		case *pizzalndv1.ImportMenuRequest_Options:
+			if options != nil || file.Len() != 0 {
+				return status.Error(codes.InvalidArgument, "the options must be sent once before the file")
+			}
+			options = part.Options
+		case *pizzalndv1.ImportMenuRequest_Chunk:
+			if options == nil {
+				return status.Error(codes.InvalidArgument, "the options must be sent before the file")
+			}
+			if file.Len()+len(part.Chunk) > maxImportSize {
+				return status.Error(codes.InvalidArgument, fmt.Sprintf("the menu file exceeds %d bytes", maxImportSize))
+			}
+			file.Write(part.Chunk)
+		}
+	}
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the options were not sent")
+	}
+
+	rows, rowErrs, err := menufile.Parse(menuFileFormats[options.GetFormat()], file.Bytes())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the rows which could not be read are reported along with the checks of the rest, so nothing is written
+	opts := models.ImportOptions{DryRun: options.GetDryRun() || len(rowErrs) != 0, Upsert: options.GetUpsert()}
+
+	summary, err := api.pizzaLand.ImportMenu(stream.Context(), rows, opts)
+	if err != nil {
+		return statusError(err)
+	}
+	summary.Errors = append(rowErrs, summary.Errors...)
+	sort.SliceStable(summary.Errors, func(i, j int) bool { return summary.Errors[i].Row < summary.Errors[j].Row })
+
+	return stream.SendAndClose(importSummaryToProto(summary))
+}
+
+func importSummaryToProto(summary models.ImportSummary) *pizzalndv1.ImportMenuResponse {
+	out := &pizzalndv1.ImportMenuResponse{
+		Applied:           summary.Applied,
+		CategoriesCreated: uint32(summary.CategoriesCreated),
+		CategoriesUpdated: uint32(summary.CategoriesUpdated),
+		PizzasCreated:     uint32(summary.PizzasCreated),
+		PizzasUpdated:     uint32(summary.PizzasUpdated),
+		Unchanged:         uint32(summary.Unchanged),
+		Error:             make([]*pizzalndv1.ImportRowError, 0, len(summary.Errors)),
+	}
+	for _, rowErr := range summary.Errors {
+		out.Error = append(out.Error, &pizzalndv1.ImportRowError{
+			Row:     uint32(rowErr.Row),
+			Line:    uint32(rowErr.Line),
+			Field:   rowErr.Field,
+			Message: rowErr.Message,
+		})
+	}
+
+	return out
+}
@@ -2,18 +2,30 @@ package pizzaland
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/apikeys"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/webhooks"
 	"github.com/nhassl3/pizzaland/internals/lib/reflection"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
 	NoIdentifier    = "None of several arguments were provided"
 	UnknownNameOrId = "An unknown Name or ID of the pizza was given"
+	NothingToUpdate = "None of the fields to update were provided"
+
+	idempotencyKeyHeader = "idempotency-key"
+	maxIdempotencyKeyLen = 255
 )
 
 type PizzaLand interface {
@@ -22,30 +34,67 @@ type PizzaLand interface {
 	GetByName(ctx context.Context, name string) (pizza *pizzalndv1.PizzaProperties, err error)
 	List(ctx context.Context, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
 	CategoryList(ctx context.Context, category string, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
-	Update(
+	CategoryListById(ctx context.Context, categoryId uint32, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
+	Update(ctx context.Context, id uint64, version uint64, update models.PizzaUpdate) (success bool, err error)
+	UpdatePizza(
 		ctx context.Context,
-		categoryId uint32,
-		name, description string,
-		typeDough *pizzalndv1.TypeDough,
-		price float32,
-		diameter uint32,
-	) (success bool, err error)
-	RemoveById(ctx context.Context, id uint64) (success bool, err error)
-	RemoveByName(ctx context.Context, name string) (success bool, err error)
+		id uint64,
+		version uint64,
+		pizza *pizzalndv1.PizzaProperties,
+		paths []string,
+	) (updated *pizzalndv1.PizzaProperties, err error)
+	RemoveById(ctx context.Context, id uint64, version uint64) (success bool, err error)
+	RemoveByName(ctx context.Context, name string, version uint64) (success bool, err error)
 	SaveCategory(ctx context.Context, category *pizzalndv1.CategoryProperties) (uint32 uint32, err error)
 	GetCategoryById(ctx context.Context, id uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
 	GetCategoryByName(ctx context.Context, name string) (pizza []*pizzalndv1.PizzaProperties, err error)
-	UpdateCategory(ctx context.Context, name, descriptions string) (success bool, err error)
-	RemoveCategoryById(ctx context.Context, id uint32) (success bool, err error)
+	UpdateCategory(ctx context.Context, id uint32, version uint64, update models.CategoryUpdate) (success bool, err error)
+	RemoveCategoryById(ctx context.Context, id uint32, version uint64) (success bool, err error)
+	RemoveCategoryByName(ctx context.Context, name string, version uint64) (success bool, err error)
+	Restore(ctx context.Context, id uint64) (success bool, err error)
+	ListDeleted(ctx context.Context, offset, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error)
+	RestoreCategory(ctx context.Context, id uint32) (success bool, err error)
+	ListDeletedCategories(ctx context.Context, offset, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error)
+	BatchSave(ctx context.Context, pizzas []*pizzalndv1.PizzaProperties, opts pizzaland.BatchOptions) (results []pizzaland.BatchResult, err error)
+	BatchUpdate(ctx context.Context, patches []pizzaland.PizzaPatch, opts pizzaland.BatchOptions) (results []pizzaland.BatchResult, err error)
+	BatchRemove(ctx context.Context, refs []pizzaland.PizzaRef, opts pizzaland.BatchOptions) (results []pizzaland.BatchResult, err error)
+	ListAuditEvents(ctx context.Context, filter models.AuditFilter, offset, limit uint32) (events []models.AuditEvent, err error)
+	WatchMenu(ctx context.Context, categories []uint32, resumeAfter uint64, send func(update pizzaland.MenuUpdate) error) error
+	ImportMenu(ctx context.Context, rows []models.MenuRow, opts models.ImportOptions) (summary models.ImportSummary, err error)
+}
+
+// Idempotency replays the stored response of the request made with an already seen key
+type Idempotency interface {
+	Do(ctx context.Context, scope, key string, request, response proto.Message, fn func(ctx context.Context) error) error
 }
 
+// ClientFunc tells the callers apart: the subject of the authenticated one or the address of the peer
+type ClientFunc func(ctx context.Context) string
+
 type ServerAPI struct {
 	pizzalndv1.UnimplementedPizzaLandServer
-	pizzaLand PizzaLand
+	pizzaLand   PizzaLand
+	idempotency Idempotency
+	client      ClientFunc
+	apiKeys     APIKeys
+	webhooks    Webhooks
 }
 
-func Register(gRPCServer *grpc.Server, pizzaLand PizzaLand) {
-	pizzalndv1.RegisterPizzaLandServer(gRPCServer, &ServerAPI{pizzaLand: pizzaLand})
+func Register(
+	gRPCServer *grpc.Server,
+	pizzaLand PizzaLand,
+	idempotency Idempotency,
+	client ClientFunc,
+	apiKeys APIKeys,
+	webhooks Webhooks,
+) {
+	pizzalndv1.RegisterPizzaLandServer(gRPCServer, &ServerAPI{
+		pizzaLand:   pizzaLand,
+		idempotency: idempotency,
+		client:      client,
+		apiKeys:     apiKeys,
+		webhooks:    webhooks,
+	})
 }
 
 func (api *ServerAPI) Save(ctx context.Context, in *pizzalndv1.SaveRequest) (*pizzalndv1.SaveResponse, error) {
@@ -53,14 +102,21 @@ func (api *ServerAPI) Save(ctx context.Context, in *pizzalndv1.SaveRequest) (*pi
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	pizzaId, err := api.pizzaLand.Save(ctx, in.GetPizza())
+	key, err := idempotencyKey(ctx, in.GetIdempotencyKey())
 	if err != nil {
-		// TODO: no internal error check
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
-		return nil, status.Error(codes.Internal, err.Error())
+	out := &pizzalndv1.SaveResponse{}
+	err = api.idempotency.Do(ctx, api.idempotencyScope(ctx, "Save"), key, in, out, func(ctx context.Context) (err error) {
+		out.PizzaId, err = api.pizzaLand.Save(ctx, in.GetPizza())
+		return err
+	})
+	if err != nil {
+		return nil, statusError(err)
 	}
 
-	return &pizzalndv1.SaveResponse{PizzaId: pizzaId}, nil
+	return out, nil
 }
 
 func (api *ServerAPI) Get(ctx context.Context, in *pizzalndv1.GetRequest) (*pizzalndv1.GetResponse, error) {
@@ -85,8 +141,7 @@ func (api *ServerAPI) Get(ctx context.Context, in *pizzalndv1.GetRequest) (*pizz
 	}
 
 	if err != nil {
-		// TODO: no internal error check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
 	return &pizzalndv1.GetResponse{Pizza: pizza}, nil
@@ -99,18 +154,20 @@ func (api *ServerAPI) List(ctx context.Context, in *pizzalndv1.ListRequest) (*pi
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	//reflection.AllFieldsIsNil(in.ProtoReflect())
-
 	var err error
-	if in.GetCategoryName() != nil {
-		pizza, err = api.pizzaLand.CategoryList(ctx, in.GetCategoryName().String(), in.GetOffset(), in.GetLimit())
-	} else {
+	switch {
+	case in.GetCategoryId() != nil && in.GetCategoryName() != nil:
+		return nil, status.Error(codes.InvalidArgument, "either category_id or category_name is expected, not both")
+	case in.GetCategoryId() != nil:
+		pizza, err = api.pizzaLand.CategoryListById(ctx, in.GetCategoryId().GetValue(), in.GetOffset(), in.GetLimit())
+	case in.GetCategoryName() != nil:
+		pizza, err = api.pizzaLand.CategoryList(ctx, in.GetCategoryName().GetValue(), in.GetOffset(), in.GetLimit())
+	default:
 		pizza, err = api.pizzaLand.List(ctx, in.GetOffset(), in.GetLimit())
 	}
 
 	if err != nil {
-		// TODO: no internal check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
 	return &pizzalndv1.ListResponse{Pizza: pizza}, nil
@@ -121,28 +178,56 @@ func (api *ServerAPI) Update(ctx context.Context, in *pizzalndv1.UpdateRequest)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if reflection.AllFieldsIsNil(in) {
-		return nil, status.Error(codes.InvalidArgument, NoIdentifier)
+	if reflection.IsEmpty(in, "pizza_id", "version") {
+		return nil, status.Error(codes.InvalidArgument, NothingToUpdate)
 	}
 
-	var (
-		categoryId  = in.GetCategoryId().GetValue()
-		name        = in.GetName().GetValue()
-		description = in.GetDescription().GetValue()
-		price       = in.GetPrice().GetValue()
-		diameter    = in.GetDiameter().GetValue()
-		typeDough   = in.GetTypeDough().Enum()
-	)
+	var update models.PizzaUpdate
+	if in.CategoryId != nil {
+		update.CategoryId = &in.CategoryId.Value
+	}
+	if in.Name != nil {
+		update.Name = &in.Name.Value
+	}
+	if in.Description != nil {
+		update.Description = &in.Description.Value
+	}
+	if in.TypeDough != nil {
+		typeDough := int32(in.GetTypeDough())
+		update.TypeDough = &typeDough
+	}
+	if in.Price != nil {
+		update.Price = &in.Price.Value
+	}
+	if in.Diameter != nil {
+		update.Diameter = &in.Diameter.Value
+	}
 
-	success, err := api.pizzaLand.Update(ctx, categoryId, name, description, typeDough, price, diameter)
+	success, err := api.pizzaLand.Update(ctx, in.GetPizzaId(), in.GetVersion(), update)
 	if err != nil {
-		// TODO: no internal error check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
 	return &pizzalndv1.UpdateResponse{Success: success}, nil
 }
 
+func (api *ServerAPI) UpdatePizza(ctx context.Context, in *pizzalndv1.UpdatePizzaRequest) (*pizzalndv1.UpdatePizzaResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if in.GetUpdateMask() == nil && reflection.IsEmpty(in.GetPizza()) {
+		return nil, status.Error(codes.InvalidArgument, NothingToUpdate)
+	}
+
+	pizza, err := api.pizzaLand.UpdatePizza(ctx, in.GetPizzaId(), in.GetVersion(), in.GetPizza(), in.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.UpdatePizzaResponse{Pizza: pizza}, nil
+}
+
 func (api *ServerAPI) Remove(ctx context.Context, in *pizzalndv1.RemoveRequest) (*pizzalndv1.RemoveResponse, error) {
 	if err := in.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -154,9 +239,9 @@ func (api *ServerAPI) Remove(ctx context.Context, in *pizzalndv1.RemoveRequest)
 	)
 	switch v := in.GetIdentifier().(type) {
 	case *pizzalndv1.RemoveRequest_PizzaId:
-		success, err = api.pizzaLand.RemoveById(ctx, v.PizzaId)
+		success, err = api.pizzaLand.RemoveById(ctx, v.PizzaId, in.GetVersion())
 	case *pizzalndv1.RemoveRequest_PizzaName:
-		success, err = api.pizzaLand.RemoveByName(ctx, v.PizzaName)
+		success, err = api.pizzaLand.RemoveByName(ctx, v.PizzaName, in.GetVersion())
 	case nil:
 		return nil, status.Error(codes.InvalidArgument, NoIdentifier)
 	default:
@@ -164,8 +249,7 @@ func (api *ServerAPI) Remove(ctx context.Context, in *pizzalndv1.RemoveRequest)
 	}
 
 	if err != nil {
-		// TODO: no internal error check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
 	return &pizzalndv1.RemoveResponse{Success: success}, nil
@@ -176,13 +260,21 @@ func (api *ServerAPI) SaveCategory(ctx context.Context, in *pizzalndv1.SaveCateg
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	categoryId, err := api.pizzaLand.SaveCategory(ctx, in.GetCategory())
+	key, err := idempotencyKey(ctx, in.GetIdempotencyKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	out := &pizzalndv1.SaveCategoryResponse{}
+	err = api.idempotency.Do(ctx, api.idempotencyScope(ctx, "SaveCategory"), key, in, out, func(ctx context.Context) (err error) {
+		out.CategoryId, err = api.pizzaLand.SaveCategory(ctx, in.GetCategory())
+		return err
+	})
 	if err != nil {
-		// TODO: implement no internal error check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
-	return &pizzalndv1.SaveCategoryResponse{CategoryId: categoryId}, nil
+	return out, nil
 }
 
 func (api *ServerAPI) GetCategory(ctx context.Context, in *pizzalndv1.GetCategoryRequest) (*pizzalndv1.GetCategoryResponse, error) {
@@ -191,30 +283,15 @@ func (api *ServerAPI) GetCategory(ctx context.Context, in *pizzalndv1.GetCategor
 	}
 
 	var (
-		pizza *pizzalndv1.ListResponse
+		pizza []*pizzalndv1.PizzaProperties
 		err   error
 	)
 
-	pizza, err = api.List(ctx, &pizzalndv1.ListRequest{
-		CategoryName: wrapperspb.String(in.GetCategoryName()),
-		Offset:       0,
-		Limit:        12,
-	})
-
 	switch v := in.GetIdentifier().(type) {
 	case *pizzalndv1.GetCategoryRequest_CategoryId:
-		// TODO: implement id in proto and here
-		//pizza, err = api.List(ctx, &pizzalndv1.ListRequest{
-		//	CategoryName: wrapperspb.String(in.GetCategoryId()),
-		//	Offset:       0,
-		//	Limit:        12,
-		//})
+		pizza, err = api.pizzaLand.GetCategoryById(ctx, v.CategoryId)
 	case *pizzalndv1.GetCategoryRequest_CategoryName:
-		pizza, err = api.List(ctx, &pizzalndv1.ListRequest{
-			CategoryName: wrapperspb.String(v.CategoryName),
-			Offset:       0,
-			Limit:        12,
-		})
+		pizza, err = api.pizzaLand.GetCategoryByName(ctx, v.CategoryName)
 	case nil:
 		return nil, status.Error(codes.InvalidArgument, NoIdentifier)
 	default:
@@ -222,17 +299,258 @@ func (api *ServerAPI) GetCategory(ctx context.Context, in *pizzalndv1.GetCategor
 	}
 
 	if err != nil {
-		// TODO: implement no internal error check
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, statusError(err)
 	}
 
-	return &pizzalndv1.GetCategoryResponse{Pizza: pizza}, nil
+	return &pizzalndv1.GetCategoryResponse{Pizza: &pizzalndv1.ListResponse{Pizza: pizza}}, nil
 }
 
 func (api *ServerAPI) UpdateCategory(ctx context.Context, in *pizzalndv1.UpdateCategoryRequest) (*pizzalndv1.UpdateCategoryResponse, error) {
-	panic("implement me")
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if reflection.IsEmpty(in, "category_id", "version") {
+		return nil, status.Error(codes.InvalidArgument, NothingToUpdate)
+	}
+
+	var update models.CategoryUpdate
+	if in.Name != nil {
+		update.Name = &in.Name.Value
+	}
+	if in.Description != nil {
+		update.Description = &in.Description.Value
+	}
+
+	success, err := api.pizzaLand.UpdateCategory(ctx, in.GetCategoryId(), in.GetVersion(), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.UpdateCategoryResponse{Success: success}, nil
 }
 
 func (api *ServerAPI) RemoveCategory(ctx context.Context, in *pizzalndv1.RemoveCategoryRequest) (*pizzalndv1.RemoveCategoryResponse, error) {
-	panic("implement me")
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		success bool
+		err     error
+	)
+	switch v := in.GetIdentifier().(type) {
+	case *pizzalndv1.RemoveCategoryRequest_CategoryId:
+		success, err = api.pizzaLand.RemoveCategoryById(ctx, uint32(v.CategoryId), in.GetVersion())
+	case *pizzalndv1.RemoveCategoryRequest_CategoryName:
+		success, err = api.pizzaLand.RemoveCategoryByName(ctx, v.CategoryName, in.GetVersion())
+	case nil:
+		return nil, status.Error(codes.InvalidArgument, NoIdentifier)
+	default:
+		return nil, status.Error(codes.InvalidArgument, UnknownNameOrId)
+	}
+
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.RemoveCategoryResponse{Success: success}, nil
+}
+
+func (api *ServerAPI) Restore(ctx context.Context, in *pizzalndv1.RestoreRequest) (*pizzalndv1.RestoreResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	success, err := api.pizzaLand.Restore(ctx, in.GetPizzaId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.RestoreResponse{Success: success}, nil
+}
+
+func (api *ServerAPI) ListDeleted(ctx context.Context, in *pizzalndv1.ListDeletedRequest) (*pizzalndv1.ListDeletedResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pizza, err := api.pizzaLand.ListDeleted(ctx, in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.ListDeletedResponse{Pizza: pizza}, nil
+}
+
+func (api *ServerAPI) RestoreCategory(ctx context.Context, in *pizzalndv1.RestoreCategoryRequest) (*pizzalndv1.RestoreCategoryResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	success, err := api.pizzaLand.RestoreCategory(ctx, in.GetCategoryId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.RestoreCategoryResponse{Success: success}, nil
+}
+
+func (api *ServerAPI) ListDeletedCategories(ctx context.Context, in *pizzalndv1.ListDeletedCategoriesRequest) (*pizzalndv1.ListDeletedCategoriesResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	categories, err := api.pizzaLand.ListDeletedCategories(ctx, in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.ListDeletedCategoriesResponse{Category: categories}, nil
+}
+
+func (api *ServerAPI) BatchSave(ctx context.Context, in *pizzalndv1.BatchSaveRequest) (*pizzalndv1.BatchSaveResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := api.pizzaLand.BatchSave(ctx, in.GetPizza(), pizzaland.BatchOptions{
+		BestEffort:   in.GetBestEffort(),
+		ValidateOnly: in.GetValidateOnly(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.BatchSaveResponse{Result: batchResults(results)}, nil
+}
+
+func (api *ServerAPI) BatchUpdate(ctx context.Context, in *pizzalndv1.BatchUpdateRequest) (*pizzalndv1.BatchUpdateResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	patches := make([]pizzaland.PizzaPatch, 0, len(in.GetPizza()))
+	for _, item := range in.GetPizza() {
+		patches = append(patches, pizzaland.PizzaPatch{
+			Id:      item.GetPizzaId(),
+			Version: item.GetVersion(),
+			Pizza:   item.GetPizza(),
+			Paths:   item.GetUpdateMask().GetPaths(),
+		})
+	}
+
+	results, err := api.pizzaLand.BatchUpdate(ctx, patches, pizzaland.BatchOptions{
+		BestEffort:   in.GetBestEffort(),
+		ValidateOnly: in.GetValidateOnly(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.BatchUpdateResponse{Result: batchResults(results)}, nil
+}
+
+func (api *ServerAPI) BatchRemove(ctx context.Context, in *pizzalndv1.BatchRemoveRequest) (*pizzalndv1.BatchRemoveResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	refs := make([]pizzaland.PizzaRef, 0, len(in.GetPizza()))
+	for _, item := range in.GetPizza() {
+		refs = append(refs, pizzaland.PizzaRef{
+			Id:      item.GetPizzaId(),
+			Name:    item.GetPizzaName(),
+			Version: item.GetVersion(),
+		})
+	}
+
+	results, err := api.pizzaLand.BatchRemove(ctx, refs, pizzaland.BatchOptions{
+		BestEffort:   in.GetBestEffort(),
+		ValidateOnly: in.GetValidateOnly(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.BatchRemoveResponse{Result: batchResults(results)}, nil
+}
+
+// idempotencyScope keeps the keys of the method apart per caller, so no caller replays the response of another one
+func (api *ServerAPI) idempotencyScope(ctx context.Context, method string) string {
+	return method + " " + api.client(ctx)
+}
+
+// idempotencyKey takes the key from the request field or from the idempotency-key metadata
+func idempotencyKey(ctx context.Context, field string) (string, error) {
+	values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyHeader)
+	if len(values) == 0 {
+		return field, nil
+	}
+
+	header := values[0]
+	switch {
+	case len(header) > maxIdempotencyKeyLen:
+		return "", fmt.Errorf("%s metadata is longer than %d", idempotencyKeyHeader, maxIdempotencyKeyLen)
+	case field != "" && field != header:
+		return "", fmt.Errorf("%s metadata does not match the request idempotency_key", idempotencyKeyHeader)
+	}
+
+	return header, nil
+}
+
+func batchResults(results []pizzaland.BatchResult) []*pizzalndv1.BatchItemResult {
+	out := make([]*pizzalndv1.BatchItemResult, 0, len(results))
+	for i, result := range results {
+		item := &pizzalndv1.BatchItemResult{
+			Index:   uint32(i),
+			Success: result.Err == nil,
+			Code:    uint32(codes.OK),
+			PizzaId: result.PizzaId,
+			Pizza:   result.Pizza,
+		}
+		if result.Err != nil {
+			st := status.Convert(statusError(result.Err))
+			item.Code, item.Error = uint32(st.Code()), st.Message()
+		}
+		out = append(out, item)
+	}
+
+	return out
+}
+
+// statusError converts domain errors into the gRPC status with a matching code
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, pizzaland.ErrPizzaNotFound),
+		errors.Is(err, pizzaland.ErrCategoryNotFound),
+		errors.Is(err, apikeys.ErrKeyNotFound),
+		errors.Is(err, webhooks.ErrWebhookNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, pizzaland.ErrPizzaExists), errors.Is(err, pizzaland.ErrCategoryExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, pizzaland.ErrVersionMismatch), errors.Is(err, idempotency.ErrKeyInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, pizzaland.ErrWatchLagged):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, pizzaland.ErrWatchClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, idempotency.ErrKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, pizzaland.ErrNothingToUpdate),
+		errors.Is(err, pizzaland.ErrInvalidUpdateMask),
+		errors.Is(err, pizzaland.ErrInvalidPizza),
+		errors.Is(err, pizzaland.ErrInvalidPizzaRef):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
 }
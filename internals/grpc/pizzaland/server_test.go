@@ -0,0 +1,175 @@
+package pizzaland_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	pizzaLandGRPC "github.com/nhassl3/pizzaland/internals/grpc/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callerHeader names the caller of the test calls, the server has no authentication
+const callerHeader = "x-test-caller"
+
+// newClient serves the API on the migrated storage with the Classic category,
+// the idempotency keys live for ttl
+func newClient(t *testing.T, ttl time.Duration) (pizzalndv1.PizzaLandClient, uint32) {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := sqlitetest.New(t)
+
+	pizzaLandObj := pizzaland.NewPizzaLand(
+		log, storage, storage, storage, storage, storage, storage,
+		interceptors.Caller, nil, nil, nil,
+	)
+	client := func(ctx context.Context) string {
+		if callers := metadata.ValueFromIncomingContext(ctx, callerHeader); len(callers) != 0 {
+			return callers[0]
+		}
+
+		return ""
+	}
+
+	server := grpc.NewServer()
+	pizzaLandGRPC.Register(server, pizzaLandObj, idempotency.New(log, storage, storage, ttl), client, nil, nil)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	api := pizzalndv1.NewPizzaLandClient(conn)
+
+	category, err := api.SaveCategory(context.Background(), &pizzalndv1.SaveCategoryRequest{
+		Category: &pizzalndv1.CategoryProperties{Name: "Classic"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return api, category.GetCategoryId()
+}
+
+// save saves the pizza with the idempotency key as the caller
+func save(api pizzalndv1.PizzaLandClient, caller, key, name string, categoryId uint32) (uint64, error) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), callerHeader, caller)
+
+	out, err := api.Save(ctx, &pizzalndv1.SaveRequest{
+		IdempotencyKey: key,
+		Pizza: &pizzalndv1.PizzaProperties{
+			CategoryId: categoryId,
+			Name:       name,
+			TypeDough:  pizzalndv1.TypeDough_THIN_DOUGH,
+			Price:      590,
+			Diameter:   30,
+		},
+	})
+
+	return out.GetPizzaId(), err
+}
+
+func TestSaveIdempotencyKey(t *testing.T) {
+	api, categoryId := newClient(t, time.Hour)
+
+	first, err := save(api, "alice", "order-1", "Margherita", categoryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		id, err := save(api, "alice", "order-1", "Margherita", categoryId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != first {
+			t.Errorf("replayed id %d, want %d", id, first)
+		}
+	})
+
+	t.Run("different payload", func(t *testing.T) {
+		_, err := save(api, "alice", "order-1", "Pepperoni", categoryId)
+		if code := status.Code(err); code != codes.FailedPrecondition {
+			t.Fatalf("code %s, want FailedPrecondition: %v", code, err)
+		}
+	})
+
+	t.Run("another caller", func(t *testing.T) {
+		// the same key of another caller is a request of its own
+		id, err := save(api, "bob", "order-1", "Pepperoni", categoryId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id == first {
+			t.Errorf("bob got the response of alice")
+		}
+	})
+}
+
+func TestSaveIdempotencyKeyExpires(t *testing.T) {
+	// the keys expire as soon as they are stored
+	api, categoryId := newClient(t, 0)
+
+	first, err := save(api, "alice", "order-1", "Margherita", categoryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := save(api, "alice", "order-1", "Pepperoni", categoryId)
+	if err != nil {
+		t.Fatalf("the expired key is still checked: %v", err)
+	}
+	if id == first {
+		t.Errorf("the expired response was replayed")
+	}
+}
+
+func TestGetCategory(t *testing.T) {
+	api, categoryId := newClient(t, time.Hour)
+
+	id, err := save(api, "alice", "", "Margherita", categoryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, in := range map[string]*pizzalndv1.GetCategoryRequest{
+		"by id":   {Identifier: &pizzalndv1.GetCategoryRequest_CategoryId{CategoryId: categoryId}},
+		"by name": {Identifier: &pizzalndv1.GetCategoryRequest_CategoryName{CategoryName: "classic"}},
+	} {
+		out, err := api.GetCategory(context.Background(), in)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if pizza := out.GetPizza().GetPizza(); len(pizza) != 1 || pizza[0].GetPizzaId().GetValue() != id {
+			t.Errorf("%s: pizzas %v, want Margherita", name, pizza)
+		}
+	}
+
+	_, err = api.GetCategory(context.Background(), &pizzalndv1.GetCategoryRequest{
+		Identifier: &pizzalndv1.GetCategoryRequest_CategoryId{CategoryId: categoryId + 1},
+	})
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("missing category: code %s, want NotFound: %v", code, err)
+	}
+}
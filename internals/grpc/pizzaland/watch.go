@@ -0,0 +1,37 @@
+package pizzaland
+
+import (
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var menuUpdateKinds = map[string]pizzalndv1.MenuUpdateKind{
+	pizzaland.UpdateSnapshot:    pizzalndv1.MenuUpdateKind_MENU_SNAPSHOT,
+	pizzaland.UpdateSnapshotEnd: pizzalndv1.MenuUpdateKind_MENU_SNAPSHOT_END,
+	pizzaland.UpdateCreated:     pizzalndv1.MenuUpdateKind_MENU_CREATED,
+	pizzaland.UpdateUpdated:     pizzalndv1.MenuUpdateKind_MENU_UPDATED,
+	pizzaland.UpdateDeleted:     pizzalndv1.MenuUpdateKind_MENU_DELETED,
+}
+
+func (api *ServerAPI) WatchMenu(in *pizzalndv1.WatchMenuRequest, stream grpc.ServerStreamingServer[pizzalndv1.WatchMenuResponse]) error {
+	if err := in.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err := api.pizzaLand.WatchMenu(stream.Context(), in.GetCategoryId(), in.GetResumeAfter(), func(update pizzaland.MenuUpdate) error {
+		out := &pizzalndv1.WatchMenuResponse{Seq: update.Seq, Kind: menuUpdateKinds[update.Kind]}
+		switch {
+		case update.Pizza != nil:
+			out.Entity = &pizzalndv1.WatchMenuResponse_Pizza{Pizza: update.Pizza}
+		case update.Category != nil:
+			out.Entity = &pizzalndv1.WatchMenuResponse_Category{Category: update.Category}
+		}
+
+		return stream.Send(out)
+	})
+
+	return statusError(err)
+}
@@ -0,0 +1,164 @@
+package pizzaland
+
+import (
+	"context"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Webhooks manages the webhooks of the partners
+type Webhooks interface {
+	Create(ctx context.Context, url string, eventTypes []string, secret string) (hook models.Webhook, generatedSecret string, err error)
+	Get(ctx context.Context, id uint64) (hook models.Webhook, err error)
+	List(ctx context.Context, offset, limit uint32) (hooks []models.Webhook, err error)
+	Update(ctx context.Context, id uint64, update models.WebhookUpdate) (hook models.Webhook, err error)
+	Delete(ctx context.Context, id uint64) error
+	ListDeliveries(ctx context.Context, webhookId uint64, status string, offset, limit uint32) (deliveries []models.WebhookDelivery, err error)
+}
+
+func (api *ServerAPI) CreateWebhook(ctx context.Context, in *pizzalndv1.CreateWebhookRequest) (*pizzalndv1.CreateWebhookResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	hook, secret, err := api.webhooks.Create(ctx, in.GetUrl(), in.GetEventTypes(), in.GetSecret())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.CreateWebhookResponse{Webhook: webhookToProto(hook), Secret: secret}, nil
+}
+
+func (api *ServerAPI) GetWebhook(ctx context.Context, in *pizzalndv1.GetWebhookRequest) (*pizzalndv1.GetWebhookResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	hook, err := api.webhooks.Get(ctx, in.GetWebhookId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.GetWebhookResponse{Webhook: webhookToProto(hook)}, nil
+}
+
+func (api *ServerAPI) ListWebhooks(ctx context.Context, in *pizzalndv1.ListWebhooksRequest) (*pizzalndv1.ListWebhooksResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	hooks, err := api.webhooks.List(ctx, in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	out := &pizzalndv1.ListWebhooksResponse{Webhook: make([]*pizzalndv1.Webhook, 0, len(hooks))}
+	for _, hook := range hooks {
+		out.Webhook = append(out.Webhook, webhookToProto(hook))
+	}
+
+	return out, nil
+}
+
+func (api *ServerAPI) UpdateWebhook(ctx context.Context, in *pizzalndv1.UpdateWebhookRequest) (*pizzalndv1.UpdateWebhookResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	update := models.WebhookUpdate{EventTypes: in.GetEventTypes()}
+	if in.Url != nil {
+		update.URL = &in.Url.Value
+	}
+	if in.Secret != nil {
+		update.Secret = &in.Secret.Value
+	}
+	if in.Enabled != nil {
+		update.Enabled = &in.Enabled.Value
+	}
+	if update.URL == nil && len(update.EventTypes) == 0 && update.Secret == nil && update.Enabled == nil {
+		return nil, status.Error(codes.InvalidArgument, NothingToUpdate)
+	}
+
+	hook, err := api.webhooks.Update(ctx, in.GetWebhookId(), update)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.UpdateWebhookResponse{Webhook: webhookToProto(hook)}, nil
+}
+
+func (api *ServerAPI) DeleteWebhook(ctx context.Context, in *pizzalndv1.DeleteWebhookRequest) (*pizzalndv1.DeleteWebhookResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := api.webhooks.Delete(ctx, in.GetWebhookId()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pizzalndv1.DeleteWebhookResponse{Success: true}, nil
+}
+
+func (api *ServerAPI) ListWebhookDeliveries(
+	ctx context.Context,
+	in *pizzalndv1.ListWebhookDeliveriesRequest,
+) (*pizzalndv1.ListWebhookDeliveriesResponse, error) {
+	if err := in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	deliveries, err := api.webhooks.ListDeliveries(ctx, in.GetWebhookId(), in.GetStatus(), in.GetOffset(), in.GetLimit())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	out := &pizzalndv1.ListWebhookDeliveriesResponse{Delivery: make([]*pizzalndv1.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		out.Delivery = append(out.Delivery, deliveryToProto(delivery))
+	}
+
+	return out, nil
+}
+
+func webhookToProto(hook models.Webhook) *pizzalndv1.Webhook {
+	out := &pizzalndv1.Webhook{
+		WebhookId:           hook.ID,
+		Url:                 hook.URL,
+		EventTypes:          hook.EventTypes,
+		Enabled:             hook.Enabled,
+		ConsecutiveFailures: uint32(hook.ConsecutiveFailures),
+		CreatedAt:           timestamppb.New(hook.CreatedAt),
+		UpdatedAt:           timestamppb.New(hook.UpdatedAt),
+	}
+	if !hook.DisabledAt.IsZero() {
+		out.DisabledAt = timestamppb.New(hook.DisabledAt)
+	}
+
+	return out
+}
+
+func deliveryToProto(delivery models.WebhookDelivery) *pizzalndv1.WebhookDelivery {
+	out := &pizzalndv1.WebhookDelivery{
+		DeliveryId:   delivery.ID,
+		WebhookId:    delivery.WebhookId,
+		EventId:      delivery.EventId,
+		EventType:    delivery.EventType,
+		Status:       delivery.Status,
+		Attempts:     uint32(delivery.Attempts),
+		ResponseCode: uint32(delivery.ResponseCode),
+		LastError:    delivery.LastError,
+		CreatedAt:    timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.Status == models.DeliveryPending {
+		out.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt)
+	}
+	if !delivery.CompletedAt.IsZero() {
+		out.CompletedAt = timestamppb.New(delivery.CompletedAt)
+	}
+
+	return out
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// codec converts the messages of the client into the binary ones the gRPC server reads and back
+type codec struct {
+	json          bool
+	input, output protoreflect.MessageType
+}
+
+// newCodec resolves the message types of the method at path "/package.Service/Method" for JSON clients
+func newCodec(path string, json bool) (*codec, error) {
+	if !json {
+		return &codec{}, nil
+	}
+
+	service, method, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %q", path)
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service %q", service)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service %q", service)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %q", path)
+	}
+
+	input, err := protoregistry.GlobalTypes.FindMessageByName(methodDesc.Input().FullName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(methodDesc.Output().FullName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &codec{json: true, input: input, output: output}, nil
+}
+
+// request converts a message of the client into the binary form
+func (c *codec) request(data []byte) ([]byte, error) {
+	if !c.json {
+		return data, nil
+	}
+
+	msg := c.input.New().Interface()
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	out, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return out, nil
+}
+
+// response converts a binary message of the server into the form of the client
+func (c *codec) response(data []byte) ([]byte, error) {
+	if !c.json {
+		return data, nil
+	}
+
+	msg := c.output.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	out, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return out, nil
+}
+
+// frame prefixes msg with the flags byte and the big endian length, as gRPC, gRPC-Web and Connect streams do
+func frame(flags byte, msg []byte) []byte {
+	out := make([]byte, 5+len(msg))
+	out[0] = flags
+	binary.BigEndian.PutUint32(out[1:5], uint32(len(msg)))
+	copy(out[5:], msg)
+
+	return out
+}
+
+// nextFrame splits the first complete frame off data
+func nextFrame(data []byte) (flags byte, msg, rest []byte, ok bool) {
+	if len(data) < 5 {
+		return 0, nil, data, false
+	}
+
+	size := binary.BigEndian.Uint32(data[1:5])
+	if uint64(len(data)-5) < uint64(size) {
+		return 0, nil, data, false
+	}
+
+	return data[0], data[5 : 5+size], data[5+size:], true
+}
@@ -0,0 +1,204 @@
+package web
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// connectUnaryProtocol buffers the single response message, since a Connect unary
+// response reports the failure with the HTTP status and a JSON body instead of trailers
+type connectUnaryProtocol struct {
+	contentType string
+	codec       *codec
+	md          http.Header
+	msg         []byte
+}
+
+func (p *connectUnaryProtocol) header(_ http.ResponseWriter, md http.Header) {
+	p.md = md
+}
+
+func (p *connectUnaryProtocol) message(_ http.ResponseWriter, msg []byte) error {
+	if p.msg != nil {
+		return status.Error(codes.Unimplemented, "unary call returned several messages")
+	}
+
+	out, err := p.codec.response(msg)
+	if err != nil {
+		return err
+	}
+	p.msg = out
+
+	return nil
+}
+
+func (p *connectUnaryProtocol) end(w http.ResponseWriter, st *status.Status, trailer http.Header) {
+	for k, v := range p.md {
+		w.Header()[k] = v
+	}
+	for k, v := range trailer {
+		w.Header()["Trailer-"+k] = v
+	}
+
+	if st.Code() == codes.OK && p.msg == nil {
+		st = status.New(codes.Unimplemented, "unary call returned no message")
+	}
+	if st.Code() != codes.OK {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(httpStatus(st.Code()))
+		_ = json.NewEncoder(w).Encode(newConnectError(st))
+		return
+	}
+
+	w.Header().Set("Content-Type", p.contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(p.msg)
+}
+
+// connectStreamProtocol sends every message in its own envelope and ends the stream with a JSON envelope
+type connectStreamProtocol struct {
+	contentType string
+	codec       *codec
+}
+
+// endStreamFlag marks the last envelope of a Connect stream
+const endStreamFlag = 0x02
+
+func (p *connectStreamProtocol) header(w http.ResponseWriter, md http.Header) {
+	for k, v := range md {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", p.contentType)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (p *connectStreamProtocol) message(w http.ResponseWriter, msg []byte) error {
+	out, err := p.codec.response(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(frame(0, out)); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+func (p *connectStreamProtocol) end(w http.ResponseWriter, st *status.Status, trailer http.Header) {
+	var end struct {
+		Error    *connectError       `json:"error,omitempty"`
+		Metadata map[string][]string `json:"metadata,omitempty"`
+	}
+	if st.Code() != codes.OK {
+		end.Error = newConnectError(st)
+	}
+	if len(trailer) != 0 {
+		end.Metadata = trailer
+	}
+
+	data, err := json.Marshal(end)
+	if err != nil {
+		data = []byte(`{"error":{"code":"internal"}}`)
+	}
+
+	_, _ = w.Write(frame(endStreamFlag, data))
+}
+
+// connectError is the JSON error of the Connect protocol
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+}
+
+type connectDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	e := &connectError{Code: codeName(st.Code()), Message: st.Message()}
+	for _, detail := range st.Proto().GetDetails() {
+		e.Details = append(e.Details, connectDetail{
+			Type:  detail.GetTypeUrl()[strings.LastIndexByte(detail.GetTypeUrl(), '/')+1:],
+			Value: base64.RawStdEncoding.EncodeToString(detail.GetValue()),
+		})
+	}
+
+	return e
+}
+
+// codeName returns the snake case name Connect uses for the code
+func codeName(code codes.Code) string {
+	switch code {
+	case codes.Canceled:
+		return "canceled"
+	case codes.InvalidArgument:
+		return "invalid_argument"
+	case codes.DeadlineExceeded:
+		return "deadline_exceeded"
+	case codes.NotFound:
+		return "not_found"
+	case codes.AlreadyExists:
+		return "already_exists"
+	case codes.PermissionDenied:
+		return "permission_denied"
+	case codes.ResourceExhausted:
+		return "resource_exhausted"
+	case codes.FailedPrecondition:
+		return "failed_precondition"
+	case codes.Aborted:
+		return "aborted"
+	case codes.OutOfRange:
+		return "out_of_range"
+	case codes.Unimplemented:
+		return "unimplemented"
+	case codes.Internal:
+		return "internal"
+	case codes.Unavailable:
+		return "unavailable"
+	case codes.DataLoss:
+		return "data_loss"
+	case codes.Unauthenticated:
+		return "unauthenticated"
+	default:
+		return "unknown"
+	}
+}
+
+// httpStatus returns the HTTP status Connect uses for the code of a failed unary call
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
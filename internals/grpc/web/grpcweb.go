@@ -0,0 +1,87 @@
+package web
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcWebProtocol passes the gRPC frames through and sends the trailers as the last frame of the body
+type grpcWebProtocol struct {
+	contentType string
+	text        bool
+}
+
+func (p *grpcWebProtocol) header(w http.ResponseWriter, md http.Header) {
+	for k, v := range md {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", p.contentType)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (p *grpcWebProtocol) message(w http.ResponseWriter, msg []byte) error {
+	return p.write(w, frame(0, msg))
+}
+
+func (p *grpcWebProtocol) end(w http.ResponseWriter, st *status.Status, trailer http.Header) {
+	var block strings.Builder
+	fmt.Fprintf(&block, "grpc-status: %d\r\n", st.Code())
+	if msg := st.Message(); msg != "" {
+		fmt.Fprintf(&block, "grpc-message: %s\r\n", encodeMessage(msg))
+	}
+	if pb := st.Proto(); len(pb.GetDetails()) != 0 {
+		if details, err := proto.Marshal(pb); err == nil {
+			fmt.Fprintf(&block, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(details))
+		}
+	}
+
+	keys := make([]string, 0, len(trailer))
+	for k := range trailer {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range trailer[k] {
+			fmt.Fprintf(&block, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+
+	// the client is gone if the trailers could not be written, so there is nobody to report to
+	_ = p.write(w, frame(0x80, []byte(block.String())))
+}
+
+func (p *grpcWebProtocol) write(w http.ResponseWriter, data []byte) error {
+	if p.text {
+		data = []byte(base64.StdEncoding.EncodeToString(data))
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// encodeMessage percent-encodes the status message the way gRPC does in the grpc-message header
+func encodeMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
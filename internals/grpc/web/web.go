@@ -0,0 +1,216 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRequestSize matches the default receive limit of the gRPC server
+const maxRequestSize = 4 << 20
+
+// Handler serves the native gRPC, gRPC-Web and Connect calls with a single gRPC server.
+// gRPC-Web and Connect calls are translated into native gRPC calls served in-process,
+// so they pass the same interceptors and handlers as the native ones.
+// Bodies of the translated requests are read whole, client streaming works for native gRPC only.
+type Handler struct {
+	grpc http.Handler
+}
+
+// NewHandler wraps the gRPC server, which serves the native calls itself through its ServeHTTP
+func NewHandler(grpcServer http.Handler) *Handler {
+	return &Handler{grpc: grpcServer}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case strings.HasPrefix(mediaType, "application/grpc-web"):
+		h.serveGRPCWeb(w, r, contentType, mediaType)
+	case strings.HasPrefix(mediaType, "application/grpc"):
+		h.grpc.ServeHTTP(w, r)
+	case strings.HasPrefix(mediaType, "application/connect+"):
+		h.serveConnectStream(w, r, mediaType)
+	case r.Method == http.MethodPost && (mediaType == "application/proto" || mediaType == "application/json"):
+		h.serveConnectUnary(w, r, mediaType)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+	}
+}
+
+func (h *Handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request, contentType, mediaType string) {
+	text := strings.HasPrefix(mediaType, "application/grpc-web-text")
+
+	body, err := readBody(r.Body, "")
+	if err == nil && text {
+		body, err = decodeBase64(body)
+	}
+	if err != nil {
+		// the gRPC-Web client expects the status in the body, so the protocol is used for the error too
+		writer := newResponseWriter(w, &grpcWebProtocol{contentType: contentType, text: text})
+		writer.fail(err)
+		return
+	}
+
+	h.forward(r, body, &grpcWebProtocol{contentType: contentType, text: text}, w)
+}
+
+func (h *Handler) serveConnectUnary(w http.ResponseWriter, r *http.Request, mediaType string) {
+	proto := &connectUnaryProtocol{contentType: mediaType}
+
+	codec, err := newCodec(r.URL.Path, mediaType == "application/json")
+	if err != nil {
+		newResponseWriter(w, proto).fail(err)
+		return
+	}
+	proto.codec = codec
+
+	body, err := readBody(r.Body, r.Header.Get("Content-Encoding"))
+	if err == nil {
+		body, err = codec.request(body)
+	}
+	if err != nil {
+		newResponseWriter(w, proto).fail(err)
+		return
+	}
+
+	h.forward(r, frame(0, body), proto, w)
+}
+
+func (h *Handler) serveConnectStream(w http.ResponseWriter, r *http.Request, mediaType string) {
+	proto := &connectStreamProtocol{contentType: mediaType}
+
+	codec, err := newCodec(r.URL.Path, mediaType == "application/connect+json")
+	if err != nil {
+		newResponseWriter(w, proto).fail(err)
+		return
+	}
+	proto.codec = codec
+
+	if encoding := r.Header.Get("Connect-Content-Encoding"); encoding != "" && encoding != "identity" {
+		newResponseWriter(w, proto).fail(status.Errorf(codes.Unimplemented, "unsupported message encoding %q", encoding))
+		return
+	}
+
+	body, err := readBody(r.Body, "")
+	if err != nil {
+		newResponseWriter(w, proto).fail(err)
+		return
+	}
+
+	// the envelopes of the Connect streams share the layout of the gRPC frames
+	var translated []byte
+	for len(body) > 0 {
+		flags, msg, rest, ok := nextFrame(body)
+		if !ok || flags != 0 {
+			newResponseWriter(w, proto).fail(status.Error(codes.InvalidArgument, "malformed request envelope"))
+			return
+		}
+
+		if msg, err = codec.request(msg); err != nil {
+			newResponseWriter(w, proto).fail(err)
+			return
+		}
+		translated, body = append(translated, frame(0, msg)...), rest
+	}
+
+	h.forward(r, translated, proto, w)
+}
+
+// forward serves the translated request with the gRPC server and writes the response in the protocol of the client
+func (h *Handler) forward(r *http.Request, body []byte, proto protocol, w http.ResponseWriter) {
+	req := r.Clone(r.Context())
+	req.Method = http.MethodPost
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Set("Te", "trailers")
+	req.Header.Del("Content-Length")
+	req.Header.Del("Content-Encoding")
+	// the responses are never compressed, the protocols of the clients have their own compression rules
+	req.Header.Del("Grpc-Accept-Encoding")
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if timeout := r.Header.Get("Connect-Timeout-Ms"); timeout != "" {
+		req.Header.Set("Grpc-Timeout", grpcTimeout(timeout))
+	}
+
+	writer := newResponseWriter(w, proto)
+	h.grpc.ServeHTTP(writer, req)
+	writer.finish()
+}
+
+func readBody(body io.Reader, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "identity":
+	case "gzip":
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		defer zr.Close()
+
+		body = zr
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "unsupported content encoding %q", encoding)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxRequestSize+1))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(data) > maxRequestSize {
+		return nil, status.Errorf(codes.ResourceExhausted, "request is larger than %d bytes", maxRequestSize)
+	}
+
+	return data, nil
+}
+
+// decodeBase64 accepts the padded and unpadded chunks the gRPC-Web text clients send
+func decodeBase64(data []byte) ([]byte, error) {
+	data = bytes.TrimRight(bytes.TrimSpace(data), "=")
+
+	decoded, err := base64.RawStdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return decoded, nil
+}
+
+// grpcTimeout converts the Connect milliseconds into the grpc-timeout value of at most 8 digits
+func grpcTimeout(ms string) string {
+	n, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return ms
+	}
+	if n < 1e8 {
+		return strconv.FormatUint(n, 10) + "m"
+	}
+
+	return strconv.FormatUint(n/1000, 10) + "S"
+}
+
+// errStatus converts err into the status written back to the client
+func errStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+
+	return status.New(codes.Internal, err.Error())
+}
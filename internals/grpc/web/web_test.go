@@ -0,0 +1,390 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/services/idempotency"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/grpc/interceptors"
+	pizzaLandGRPC "github.com/nhassl3/pizzaland/internals/grpc/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/grpc/web"
+	"github.com/nhassl3/pizzaland/internals/lib/hub"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// fixture is the PizzaLand API behind the web handler with a single pizza in the menu
+type fixture struct {
+	url     string
+	pizzaId uint64
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := sqlitetest.New(t)
+
+	menu := hub.New[pizzaland.MenuChange](10, 10, 0)
+	t.Cleanup(menu.Close)
+
+	pizzaLandObj := pizzaland.NewPizzaLand(
+		log, storage, storage, storage, storage, storage, storage,
+		interceptors.Caller, nil, nil, menu,
+	)
+
+	ctx := context.Background()
+	categoryId, err := pizzaLandObj.SaveCategory(ctx, &pizzalndv1.CategoryProperties{Name: "Classic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pizzaId, err := pizzaLandObj.Save(ctx, &pizzalndv1.PizzaProperties{
+		CategoryId: categoryId,
+		Name:       "Margherita",
+		TypeDough:  pizzalndv1.TypeDough_THIN_DOUGH,
+		Price:      590,
+		Diameter:   30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer()
+	client := func(context.Context) string { return "" }
+	pizzaLandGRPC.Register(grpcServer, pizzaLandObj, idempotency.New(log, storage, storage, time.Hour), client, nil, nil)
+	t.Cleanup(grpcServer.Stop)
+
+	server := httptest.NewServer(web.NewHandler(grpcServer))
+	t.Cleanup(server.Close)
+
+	return &fixture{url: server.URL, pizzaId: pizzaId}
+}
+
+// post calls the method of the PizzaLand service and returns the response with its whole body
+func (f *fixture) post(t *testing.T, method, contentType string, body []byte, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, f.url+"/"+pizzalndv1.PizzaLand_ServiceDesc.ServiceName+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, data
+}
+
+func TestGRPCWeb(t *testing.T) {
+	f := newFixture(t)
+
+	for _, text := range []bool{false, true} {
+		contentType := "application/grpc-web+proto"
+		if text {
+			contentType = "application/grpc-web-text+proto"
+		}
+
+		// call sends the single request message and splits the response into the messages and the trailers
+		call := func(t *testing.T, method string, in proto.Message, header http.Header) ([][]byte, string) {
+			t.Helper()
+
+			body := envelope(0, marshal(t, in))
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+
+			resp, data := f.post(t, method, contentType, body, header)
+			if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != contentType {
+				t.Fatalf("response %d %q, want 200 %q", resp.StatusCode, resp.Header.Get("Content-Type"), contentType)
+			}
+			if text {
+				data = decodeChunks(t, data)
+			}
+
+			var (
+				msgs     [][]byte
+				trailers string
+			)
+			for _, e := range envelopes(t, data) {
+				if e.flags&0x80 != 0 {
+					trailers = string(e.msg)
+					continue
+				}
+				msgs = append(msgs, e.msg)
+			}
+
+			return msgs, trailers
+		}
+
+		t.Run(contentType+" unary", func(t *testing.T) {
+			msgs, trailers := call(t, "Get", &pizzalndv1.GetRequest{
+				Identifier: &pizzalndv1.GetRequest_PizzaId{PizzaId: f.pizzaId},
+			}, nil)
+
+			if !strings.Contains(trailers, "grpc-status: 0\r\n") {
+				t.Fatalf("trailers %q, want status 0", trailers)
+			}
+			if len(msgs) != 1 {
+				t.Fatalf("%d messages, want 1", len(msgs))
+			}
+
+			var out pizzalndv1.GetResponse
+			unmarshal(t, msgs[0], &out)
+			if out.GetPizza().GetName() != "Margherita" {
+				t.Errorf("pizza %v, want Margherita", out.GetPizza())
+			}
+		})
+
+		t.Run(contentType+" error status", func(t *testing.T) {
+			msgs, trailers := call(t, "Get", &pizzalndv1.GetRequest{
+				Identifier: &pizzalndv1.GetRequest_PizzaId{PizzaId: f.pizzaId + 1},
+			}, nil)
+
+			if len(msgs) != 0 {
+				t.Errorf("%d messages with the error, want none", len(msgs))
+			}
+			if !strings.Contains(trailers, "grpc-status: 5\r\n") || !strings.Contains(trailers, "grpc-message: ") {
+				t.Errorf("trailers %q, want NotFound with its message", trailers)
+			}
+		})
+
+		t.Run(contentType+" server stream", func(t *testing.T) {
+			// the deadline ends the watch, which otherwise waits for the changes forever
+			header := http.Header{"Grpc-Timeout": {"300m"}}
+			msgs, trailers := call(t, "WatchMenu", &pizzalndv1.WatchMenuRequest{}, header)
+
+			assertSnapshot(t, msgs, func(msg []byte, out *pizzalndv1.WatchMenuResponse) { unmarshal(t, msg, out) })
+			if !strings.Contains(trailers, "grpc-status: 4\r\n") {
+				t.Errorf("trailers %q, want DeadlineExceeded", trailers)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	f := newFixture(t)
+	get := &pizzalndv1.GetRequest{Identifier: &pizzalndv1.GetRequest_PizzaId{PizzaId: f.pizzaId}}
+
+	t.Run("unary proto", func(t *testing.T) {
+		resp, data := f.post(t, "Get", "application/proto", marshal(t, get), nil)
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/proto" {
+			t.Fatalf("response %d %q: %s", resp.StatusCode, resp.Header.Get("Content-Type"), data)
+		}
+
+		var out pizzalndv1.GetResponse
+		unmarshal(t, data, &out)
+		if out.GetPizza().GetName() != "Margherita" {
+			t.Errorf("pizza %v, want Margherita", out.GetPizza())
+		}
+	})
+
+	t.Run("unary json", func(t *testing.T) {
+		resp, data := f.post(t, "Get", "application/json", marshalJSON(t, get), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("response %d: %s", resp.StatusCode, data)
+		}
+
+		var out pizzalndv1.GetResponse
+		if err := protojson.Unmarshal(data, &out); err != nil {
+			t.Fatalf("response %s: %v", data, err)
+		}
+		if out.GetPizza().GetName() != "Margherita" {
+			t.Errorf("pizza %v, want Margherita", out.GetPizza())
+		}
+	})
+
+	t.Run("unary error status", func(t *testing.T) {
+		missing := &pizzalndv1.GetRequest{Identifier: &pizzalndv1.GetRequest_PizzaId{PizzaId: f.pizzaId + 1}}
+		resp, data := f.post(t, "Get", "application/json", marshalJSON(t, missing), nil)
+
+		var connectErr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(data, &connectErr); err != nil {
+			t.Fatalf("error body %s: %v", data, err)
+		}
+		if resp.StatusCode != http.StatusNotFound || connectErr.Code != "not_found" || connectErr.Message == "" {
+			t.Errorf("response %d %s, want 404 not_found with the message", resp.StatusCode, data)
+		}
+	})
+
+	t.Run("server stream", func(t *testing.T) {
+		// the deadline ends the watch, which otherwise waits for the changes forever
+		header := http.Header{"Connect-Timeout-Ms": {"300"}}
+		resp, data := f.post(t, "WatchMenu", "application/connect+json", envelope(0, []byte(`{}`)), header)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("response %d: %s", resp.StatusCode, data)
+		}
+
+		var (
+			msgs [][]byte
+			end  []byte
+		)
+		for _, e := range envelopes(t, data) {
+			if e.flags&0x02 != 0 {
+				end = e.msg
+				continue
+			}
+			msgs = append(msgs, e.msg)
+		}
+
+		assertSnapshot(t, msgs, func(msg []byte, out *pizzalndv1.WatchMenuResponse) {
+			if err := protojson.Unmarshal(msg, out); err != nil {
+				t.Fatalf("message %s: %v", msg, err)
+			}
+		})
+
+		var endStream struct {
+			Error *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(end, &endStream); err != nil {
+			t.Fatalf("end of the stream %q: %v", end, err)
+		}
+		if endStream.Error == nil || endStream.Error.Code != "deadline_exceeded" {
+			t.Errorf("end of the stream %s, want deadline_exceeded", end)
+		}
+	})
+}
+
+// assertSnapshot checks the watch sent the category, the pizza and the end of the snapshot
+func assertSnapshot(t *testing.T, msgs [][]byte, decode func(msg []byte, out *pizzalndv1.WatchMenuResponse)) {
+	t.Helper()
+
+	var kinds []pizzalndv1.MenuUpdateKind
+	var names []string
+	for _, msg := range msgs {
+		var out pizzalndv1.WatchMenuResponse
+		decode(msg, &out)
+
+		kinds = append(kinds, out.GetKind())
+		switch {
+		case out.GetPizza() != nil:
+			names = append(names, out.GetPizza().GetName())
+		case out.GetCategory() != nil:
+			names = append(names, out.GetCategory().GetName())
+		}
+	}
+
+	if len(kinds) != 3 || kinds[2] != pizzalndv1.MenuUpdateKind_MENU_SNAPSHOT_END {
+		t.Fatalf("updates %v, want two snapshot updates and its end", kinds)
+	}
+	if strings.Join(names, ",") != "Classic,Margherita" && strings.Join(names, ",") != "Margherita,Classic" {
+		t.Errorf("snapshot of %q, want Classic and Margherita", names)
+	}
+}
+
+type envelopeFrame struct {
+	flags byte
+	msg   []byte
+}
+
+func envelope(flags byte, msg []byte) []byte {
+	out := make([]byte, 5, 5+len(msg))
+	out[0] = flags
+	binary.BigEndian.PutUint32(out[1:5], uint32(len(msg)))
+
+	return append(out, msg...)
+}
+
+// envelopes splits the body into the length-prefixed frames, which must fill it whole
+func envelopes(t *testing.T, data []byte) []envelopeFrame {
+	t.Helper()
+
+	var frames []envelopeFrame
+	for len(data) > 0 {
+		if len(data) < 5 {
+			t.Fatalf("truncated frame header %q", data)
+		}
+		size := int(binary.BigEndian.Uint32(data[1:5]))
+		if len(data)-5 < size {
+			t.Fatalf("truncated frame of %d bytes", size)
+		}
+		frames = append(frames, envelopeFrame{flags: data[0], msg: data[5 : 5+size]})
+		data = data[5+size:]
+	}
+
+	return frames
+}
+
+// decodeChunks decodes the text body, which is made of the padded base64 chunks of every write
+func decodeChunks(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var out []byte
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '=')
+		if end < 0 {
+			end = len(data)
+		}
+		for end < len(data) && data[end] == '=' {
+			end++
+		}
+
+		chunk, err := base64.StdEncoding.DecodeString(string(data[:end]))
+		if err != nil {
+			t.Fatalf("text body %q: %v", data, err)
+		}
+		out, data = append(out, chunk...), data[end:]
+	}
+
+	return out
+}
+
+func marshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func marshalJSON(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func unmarshal(t *testing.T, data []byte, m proto.Message) {
+	t.Helper()
+
+	if err := proto.Unmarshal(data, m); err != nil {
+		t.Fatal(err)
+	}
+}
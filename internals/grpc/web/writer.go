@@ -0,0 +1,195 @@
+package web
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// protocol writes the parts of a gRPC response in the wire format of the client
+type protocol interface {
+	// header is called once with the response metadata before anything else is written
+	header(w http.ResponseWriter, md http.Header)
+	// message is called with every binary response message
+	message(w http.ResponseWriter, msg []byte) error
+	// end is called once with the status and the trailer metadata of the call
+	end(w http.ResponseWriter, st *status.Status, trailer http.Header)
+}
+
+// responseWriter is handed to the gRPC server in place of the client connection.
+// The server writes the headers, the length-prefixed messages and the trailers into it,
+// and it passes them on to the protocol of the client.
+type responseWriter struct {
+	w       http.ResponseWriter
+	proto   protocol
+	headers http.Header
+	// code is the HTTP status of the gRPC server, it is not 200 only if the server rejected the request itself
+	code     int
+	sent     bool
+	pending  []byte
+	rejected []byte
+	err      error
+}
+
+func newResponseWriter(w http.ResponseWriter, proto protocol) *responseWriter {
+	return &responseWriter{w: w, proto: proto, headers: make(http.Header), code: http.StatusOK}
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.headers
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if !rw.sent {
+		rw.code = code
+	}
+	rw.sendHeader()
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.sendHeader()
+
+	if rw.code != http.StatusOK {
+		rw.rejected = append(rw.rejected, b...)
+		return len(b), nil
+	}
+	if rw.err != nil {
+		return 0, rw.err
+	}
+
+	rw.pending = append(rw.pending, b...)
+	for {
+		flags, msg, rest, ok := nextFrame(rw.pending)
+		if !ok {
+			break
+		}
+		if flags != 0 {
+			rw.err = status.Error(codes.Internal, "compressed response messages are not supported")
+			return 0, rw.err
+		}
+		if err := rw.proto.message(rw.w, msg); err != nil {
+			rw.err = err
+			return 0, err
+		}
+		rw.pending = rest
+	}
+
+	return len(b), nil
+}
+
+// Flush only commits the headers, the protocols flush the client connection themselves,
+// since a Connect unary response must not be sent before its status is known
+func (rw *responseWriter) Flush() {
+	rw.sendHeader()
+}
+
+// sendHeader passes on the response metadata once the gRPC server committed its headers
+func (rw *responseWriter) sendHeader() {
+	if rw.sent {
+		return
+	}
+	rw.sent = true
+
+	if rw.code != http.StatusOK {
+		return
+	}
+
+	md := make(http.Header)
+	for k, v := range rw.headers {
+		if isMetadata(k) {
+			md[k] = v
+		}
+	}
+	rw.proto.header(rw.w, md)
+}
+
+// finish ends the response with the status the gRPC server left in the trailers
+func (rw *responseWriter) finish() {
+	switch {
+	case rw.err != nil:
+		rw.fail(rw.err)
+		return
+	case rw.code != http.StatusOK:
+		rw.fail(status.Errorf(codes.Internal, "gRPC server rejected the request: %d %s", rw.code, strings.TrimSpace(string(rw.rejected))))
+		return
+	case len(rw.pending) != 0:
+		rw.fail(status.Error(codes.Internal, "truncated response message"))
+		return
+	}
+	rw.sendHeader()
+
+	trailer := make(http.Header)
+	for k, v := range rw.headers {
+		if name, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			trailer[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+
+	rw.proto.end(rw.w, trailerStatus(rw.headers), trailer)
+}
+
+// fail ends the response with err, the headers are sent first if they were not yet
+func (rw *responseWriter) fail(err error) {
+	if !rw.sent || rw.code != http.StatusOK {
+		rw.sent, rw.code = true, http.StatusOK
+		rw.proto.header(rw.w, make(http.Header))
+	}
+
+	rw.proto.end(rw.w, errStatus(err), make(http.Header))
+}
+
+// trailerStatus restores the status the gRPC server wrote into the Grpc-* trailers
+func trailerStatus(h http.Header) *status.Status {
+	if details := h.Get("Grpc-Status-Details-Bin"); details != "" {
+		if st, ok := decodeStatusDetails(details); ok {
+			return st
+		}
+	}
+
+	code, err := strconv.ParseUint(h.Get("Grpc-Status"), 10, 32)
+	if err != nil {
+		return status.New(codes.Unknown, "gRPC server did not report the status")
+	}
+
+	msg := h.Get("Grpc-Message")
+	if decoded, err := url.PathUnescape(msg); err == nil {
+		msg = decoded
+	}
+
+	return status.New(codes.Code(code), msg)
+}
+
+func decodeStatusDetails(value string) (*status.Status, bool) {
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, false
+	}
+
+	var pb spb.Status
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return nil, false
+	}
+
+	return status.FromProto(&pb), true
+}
+
+// isMetadata reports whether the response header carries the metadata of the handler
+func isMetadata(key string) bool {
+	key = strings.ToLower(key)
+
+	switch {
+	case strings.HasPrefix(key, strings.ToLower(http.TrailerPrefix)),
+		strings.HasPrefix(key, "grpc-"),
+		key == "content-type", key == "content-length", key == "trailer", key == "te", key == "date":
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,156 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval limits how often the files are checked for changes, the check runs on the TLS handshake
+const checkInterval = time.Second
+
+// Reloader serves the TLS configuration built from the certificate files
+// and rebuilds it when any of the files changes on disk.
+// A change that fails to load is logged and the previous configuration stays in use.
+type Reloader struct {
+	log                             *slog.Logger
+	certFile, keyFile, clientCAFile string
+
+	mu      sync.Mutex
+	config  *tls.Config
+	stamp   string
+	checked time.Time
+}
+
+// NewReloader loads the server key pair and, when clientCAFile is set,
+// the CA bundle used to verify the required client certificates
+func NewReloader(log *slog.Logger, certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	const op = "certs.NewReloader"
+
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("%s: both certificate and key files are required", op)
+	}
+
+	r := &Reloader{
+		log:          log,
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	stamp, err := r.stampFiles()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if r.config, err = r.load(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	r.stamp, r.checked = stamp, time.Now()
+
+	return r, nil
+}
+
+// TLSConfig returns the server configuration which picks up the reloaded files on every handshake.
+// nextProtos replace the "h2" offered to the clients by default.
+func (r *Reloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config := r.current()
+			if len(nextProtos) != 0 {
+				config = config.Clone()
+				config.NextProtos = nextProtos
+			}
+
+			return config, nil
+		},
+	}
+}
+
+func (r *Reloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < checkInterval {
+		return r.config
+	}
+	r.checked = time.Now()
+
+	log := r.log.With(slog.String("op", "certs.Reloader"), slog.String("cert_file", r.certFile))
+
+	stamp, err := r.stampFiles()
+	if err != nil {
+		log.Error("failed to check certificate files", slog.String("error", err.Error()))
+		return r.config
+	}
+	if stamp == r.stamp {
+		return r.config
+	}
+
+	config, err := r.load()
+	if err != nil {
+		// the files may be in the middle of being replaced, retry on the next check
+		log.Error("failed to reload certificates", slog.String("error", err.Error()))
+		return r.config
+	}
+
+	r.config, r.stamp = config, stamp
+	log.Info("certificates reloaded")
+
+	return r.config
+}
+
+func (r *Reloader) load() (*tls.Config, error) {
+	pair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pair},
+		// the configuration returned for the client replaces the one of the gRPC credentials
+		NextProtos: []string{"h2"},
+	}
+
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + r.clientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// stampFiles sums up the size and modification time of the files, it changes whenever any file is rewritten
+func (r *Reloader) stampFiles() (string, error) {
+	var stamp string
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return stamp, nil
+}
@@ -0,0 +1,118 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files are the paths of the PEM files written by WriteSelfSigned
+type Files struct {
+	CAFile         string
+	CertFile       string
+	KeyFile        string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// WriteSelfSigned writes a throwaway CA together with the server and client certificates signed by it into dir.
+// The server certificate is valid for hosts, which are IP addresses or DNS names.
+// It is meant for the integration tests and local mutual TLS setups, never for production.
+func WriteSelfSigned(dir string, hosts ...string) (Files, error) {
+	const op = "certs.WriteSelfSigned"
+
+	files := Files{
+		CAFile:         filepath.Join(dir, "ca.pem"),
+		CertFile:       filepath.Join(dir, "server.pem"),
+		KeyFile:        filepath.Join(dir, "server-key.pem"),
+		ClientCertFile: filepath.Join(dir, "client.pem"),
+		ClientKeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return Files{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	caTemplate := template("pizzaland test CA")
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return Files{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := writePEM(files.CAFile, "CERTIFICATE", caDER); err != nil {
+		return Files{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	serverTemplate := template("pizzaland server")
+	serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			serverTemplate.IPAddresses = append(serverTemplate.IPAddresses, ip)
+		} else {
+			serverTemplate.DNSNames = append(serverTemplate.DNSNames, host)
+		}
+	}
+	if err := writeSigned(files.CertFile, files.KeyFile, serverTemplate, caTemplate, caKey); err != nil {
+		return Files{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clientTemplate := template("pizzaland client")
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if err := writeSigned(files.ClientCertFile, files.ClientKeyFile, clientTemplate, caTemplate, caKey); err != nil {
+		return Files{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return files, nil
+}
+
+func template(commonName string) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+func writeSigned(certFile, keyFile string, cert, ca *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, cert, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := writePEM(certFile, "CERTIFICATE", der); err != nil {
+		return err
+	}
+
+	return writePEM(keyFile, "PRIVATE KEY", keyDER)
+}
+
+func writePEM(file, blockType string, der []byte) error {
+	return os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+}
@@ -0,0 +1,241 @@
+package hub
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// gapTimeout is how long the messages after a missing sequence number are held back,
+// the number is skipped afterwards, so a message which never comes does not stall the subscribers
+const gapTimeout = 5 * time.Second
+
+var (
+	// ErrSlow ends the subscription which did not keep up with the published messages
+	ErrSlow = errors.New("subscriber is too slow")
+	// ErrClosed ends the subscriptions of the closed hub
+	ErrClosed = errors.New("hub is closed")
+)
+
+// Message is a value published under its sequence number
+type Message[T any] struct {
+	Seq   uint64
+	Value T
+}
+
+// Hub fans the published messages out to the subscribers and keeps the latest ones,
+// so the subscribers coming back after a disconnect get the messages they missed.
+// The sequence numbers are expected to grow by one with every message, the messages may be published
+// out of order but they are sent in the order of the numbers.
+type Hub[T any] struct {
+	mu      sync.Mutex
+	history []Message[T]
+	size    int
+	buffer  int
+	last    uint64
+	subs    map[*Subscription[T]]struct{}
+	closed  bool
+
+	// pending holds the messages published before the ones with the lower numbers
+	pending    map[uint64]Message[T]
+	gapTimeout time.Duration
+	gapTimer   *time.Timer
+}
+
+// New creates the hub keeping the history latest messages, every subscriber may lag behind
+// by at most buffer messages. last is the sequence number published before the hub was created.
+func New[T any](history, buffer int, last uint64) *Hub[T] {
+	return &Hub[T]{
+		size:       history,
+		buffer:     buffer,
+		last:       last,
+		subs:       make(map[*Subscription[T]]struct{}),
+		pending:    make(map[uint64]Message[T]),
+		gapTimeout: gapTimeout,
+	}
+}
+
+// Publish sends the message to every subscriber without blocking, the subscribers with the full buffer are dropped.
+// The message is held back until the one with the previous number is published,
+// the messages with the numbers already sent or skipped are discarded.
+func (h *Hub[T]) Publish(seq uint64, value T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed || seq <= h.last {
+		return
+	}
+
+	h.pending[seq] = Message[T]{Seq: seq, Value: value}
+	h.flush()
+
+	switch {
+	case len(h.pending) == 0 && h.gapTimer != nil:
+		h.gapTimer.Stop()
+		h.gapTimer = nil
+	case len(h.pending) != 0 && h.gapTimer == nil:
+		h.gapTimer = time.AfterFunc(h.gapTimeout, h.skipGap)
+	}
+}
+
+// flush sends the pending messages following the last sent one
+func (h *Hub[T]) flush() {
+	for {
+		msg, ok := h.pending[h.last+1]
+		if !ok {
+			return
+		}
+		delete(h.pending, msg.Seq)
+
+		h.send(msg)
+	}
+}
+
+// skipGap gives up on the missing numbers before the lowest pending message
+func (h *Hub[T]) skipGap() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.gapTimer = nil
+	if h.closed || len(h.pending) == 0 {
+		return
+	}
+
+	next := uint64(0)
+	for seq := range h.pending {
+		if next == 0 || seq < next {
+			next = seq
+		}
+	}
+	h.last = next - 1
+	h.flush()
+
+	// the messages after the next gap wait for their own timeout
+	if len(h.pending) != 0 {
+		h.gapTimer = time.AfterFunc(h.gapTimeout, h.skipGap)
+	}
+}
+
+func (h *Hub[T]) send(msg Message[T]) {
+	h.remember(msg)
+	h.last = msg.Seq
+
+	for sub := range h.subs {
+		select {
+		case sub.c <- msg:
+		default:
+			h.drop(sub, ErrSlow)
+		}
+	}
+}
+
+// Subscribe registers the subscriber interested in the messages after the sequence number after.
+// replay holds the kept messages after it and ok is false when some of them are no longer kept
+// or after is unknown. last is the sequence number of the latest message, the later ones go to the subscription.
+func (h *Hub[T]) Subscribe(after uint64) (sub *Subscription[T], replay []Message[T], last uint64, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub = &Subscription[T]{
+		hub:  h,
+		c:    make(chan Message[T], h.buffer),
+		done: make(chan struct{}),
+	}
+	if h.closed {
+		sub.err = ErrClosed
+		close(sub.done)
+		return sub, nil, h.last, false
+	}
+	h.subs[sub] = struct{}{}
+
+	switch {
+	case after > h.last:
+		return sub, nil, h.last, false
+	case after == h.last:
+		return sub, nil, h.last, true
+	case len(h.history) == 0 || h.history[0].Seq > after+1:
+		return sub, nil, h.last, false
+	}
+
+	i := sort.Search(len(h.history), func(i int) bool { return h.history[i].Seq > after })
+	replay = append([]Message[T](nil), h.history[i:]...)
+
+	return sub, replay, h.last, true
+}
+
+// Close ends every subscription with ErrClosed, the later messages are discarded
+func (h *Hub[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	if h.gapTimer != nil {
+		h.gapTimer.Stop()
+		h.gapTimer = nil
+	}
+	clear(h.pending)
+
+	for sub := range h.subs {
+		h.drop(sub, ErrClosed)
+	}
+}
+
+// remember keeps the message in the history, the messages are sent in the order of the sequence numbers
+func (h *Hub[T]) remember(msg Message[T]) {
+	if h.size <= 0 {
+		return
+	}
+
+	h.history = append(h.history, msg)
+
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+}
+
+func (h *Hub[T]) drop(sub *Subscription[T], err error) {
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.done)
+}
+
+// Subscription receives the messages published after it was made
+type Subscription[T any] struct {
+	hub  *Hub[T]
+	c    chan Message[T]
+	done chan struct{}
+	// err is set before done is closed
+	err error
+}
+
+// C receives the messages
+func (s *Subscription[T]) C() <-chan Message[T] {
+	return s.c
+}
+
+// Done is closed when the hub drops the subscription, Err tells why
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err is ErrSlow or ErrClosed once Done is closed
+func (s *Subscription[T]) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close unsubscribes
+func (s *Subscription[T]) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if _, ok := s.hub.subs[s]; ok {
+		delete(s.hub.subs, s)
+		close(s.done)
+	}
+}
@@ -0,0 +1,75 @@
+package hub
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublishInOrder(t *testing.T) {
+	h := New[string](10, 10, 4)
+	defer h.Close()
+
+	sub, _, _, _ := h.Subscribe(4)
+	defer sub.Close()
+
+	// the transactions committed as 5, 6 and 7 run their callbacks in another order
+	h.Publish(7, "g")
+	h.Publish(6, "f")
+	assertNothing(t, sub)
+
+	h.Publish(5, "e")
+	h.Publish(6, "f again")
+	assertSeqs(t, sub, 5, 6, 7)
+
+	_, replay, last, ok := h.Subscribe(4)
+	if !ok || last != 7 || len(replay) != 3 || replay[0].Seq != 5 || replay[2].Seq != 7 {
+		t.Errorf("replay %v, last %d, ok %t, want 5 to 7", replay, last, ok)
+	}
+}
+
+func TestSkipGap(t *testing.T) {
+	h := New[string](10, 10, 0)
+	defer h.Close()
+	h.gapTimeout = 50 * time.Millisecond
+
+	sub, _, _, _ := h.Subscribe(0)
+	defer sub.Close()
+
+	h.Publish(1, "a")
+	h.Publish(3, "c")
+	h.Publish(5, "e")
+	assertSeqs(t, sub, 1)
+
+	// 2 never comes, so 3 is sent after the timeout, and 5 waits for 4 as long again
+	assertSeqs(t, sub, 3)
+	assertSeqs(t, sub, 5)
+
+	h.Publish(2, "b")
+	h.Publish(6, "f")
+	assertSeqs(t, sub, 6)
+}
+
+func assertSeqs(t *testing.T, sub *Subscription[string], want ...uint64) {
+	t.Helper()
+
+	for _, seq := range want {
+		select {
+		case msg := <-sub.C():
+			if msg.Seq != seq {
+				t.Fatalf("got message %d, want %d", msg.Seq, seq)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no message %d", seq)
+		}
+	}
+}
+
+func assertNothing(t *testing.T, sub *Subscription[string]) {
+	t.Helper()
+
+	select {
+	case msg := <-sub.C():
+		t.Fatalf("got message %d before the previous ones", msg.Seq)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
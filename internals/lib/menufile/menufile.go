@@ -0,0 +1,304 @@
+// Package menufile reads the menu rows from the CSV, JSON and YAML files
+package menufile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// the supported formats
+const (
+	CSV  = "csv"
+	JSON = "json"
+	YAML = "yaml"
+)
+
+// Columns are the CSV header names and the JSON and YAML keys of the rows, only kind and name are required
+var Columns = []string{"kind", "category", "name", "description", "type_dough", "price", "diameter"}
+
+// SyntaxError is the file which cannot be read at all
+type SyntaxError struct {
+	Line int
+	Err  error
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return "malformed menu file: " + e.Err.Error()
+	}
+
+	return fmt.Sprintf("malformed menu file at line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// fileRow is a row as written in the file
+type fileRow struct {
+	Kind        string  `json:"kind" yaml:"kind"`
+	Category    string  `json:"category" yaml:"category"`
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description" yaml:"description"`
+	TypeDough   string  `json:"type_dough" yaml:"type_dough"`
+	Price       float32 `json:"price" yaml:"price"`
+	Diameter    uint32  `json:"diameter" yaml:"diameter"`
+}
+
+// Parse reads the rows of the file in the format. The rows which cannot be read are left out and reported
+// as errors, so the rest is still checked; a file which cannot be read at all fails with *SyntaxError.
+// CSV needs the header row, JSON and YAML hold the list of the rows.
+func Parse(format string, data []byte) (rows []models.MenuRow, rowErrs []models.ImportError, err error) {
+	switch format {
+	case CSV:
+		return parseCSV(data)
+	case JSON:
+		return parseJSON(data)
+	case YAML:
+		return parseYAML(data)
+	default:
+		return nil, nil, fmt.Errorf("unknown menu file format %q", format)
+	}
+}
+
+func parseCSV(data []byte) (rows []models.MenuRow, rowErrs []models.ImportError, err error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, &SyntaxError{Err: errors.New("the header row is missing")}
+		}
+
+		return nil, nil, csvError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isColumn(name) {
+			return nil, nil, &SyntaxError{Line: 1, Err: fmt.Errorf("unknown column %q", name)}
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"kind", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, &SyntaxError{Line: 1, Err: fmt.Errorf("the %q column is missing", required)}
+		}
+	}
+
+	for n := 1; ; n++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, csvError(err)
+		}
+		line, _ := r.FieldPos(0)
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		file := fileRow{
+			Kind:        value("kind"),
+			Category:    value("category"),
+			Name:        value("name"),
+			Description: value("description"),
+			TypeDough:   value("type_dough"),
+		}
+
+		var errs []models.ImportError
+		if v := value("price"); v != "" {
+			price, err := strconv.ParseFloat(v, 32)
+			if err != nil {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Field: "price", Message: "not a number"})
+			}
+			file.Price = float32(price)
+		}
+		if v := value("diameter"); v != "" {
+			diameter, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Field: "diameter", Message: "not a whole number"})
+			}
+			file.Diameter = uint32(diameter)
+		}
+
+		rows, rowErrs = appendRow(rows, rowErrs, file, n, line, errs)
+	}
+
+	return rows, rowErrs, nil
+}
+
+func parseJSON(data []byte) (rows []models.MenuRow, rowErrs []models.ImportError, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+		return nil, nil, &SyntaxError{Line: lineAt(data, 0), Err: errors.New("the list of the rows is expected")}
+	}
+
+	for n := 1; dec.More(); n++ {
+		line := lineAt(data, dec.InputOffset())
+
+		var (
+			raw  json.RawMessage
+			file fileRow
+		)
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, &SyntaxError{Line: line, Err: err}
+		}
+
+		// the row is decoded on its own, so its mistakes do not stop the rest of the file
+		var errs []models.ImportError
+		rowDec := json.NewDecoder(bytes.NewReader(raw))
+		rowDec.DisallowUnknownFields()
+		if err := rowDec.Decode(&file); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()})
+			} else {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Message: strings.TrimPrefix(err.Error(), "json: ")})
+			}
+		}
+
+		rows, rowErrs = appendRow(rows, rowErrs, file, n, line, errs)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, &SyntaxError{Line: lineAt(data, dec.InputOffset()), Err: err}
+	}
+
+	return rows, rowErrs, nil
+}
+
+func parseYAML(data []byte) (rows []models.MenuRow, rowErrs []models.ImportError, err error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, &SyntaxError{Err: err}
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, nil, &SyntaxError{Line: list.Line, Err: errors.New("the list of the rows is expected")}
+	}
+
+	for i, node := range list.Content {
+		n, line := i+1, node.Line
+
+		var errs []models.ImportError
+		if node.Kind != yaml.MappingNode {
+			errs = append(errs, models.ImportError{Row: n, Line: line, Message: "the row must be a mapping"})
+			rowErrs = append(rowErrs, errs...)
+			continue
+		}
+
+		for k := 0; k < len(node.Content); k += 2 {
+			if key := node.Content[k]; !isColumn(key.Value) {
+				errs = append(errs, models.ImportError{Row: n, Line: key.Line, Field: key.Value, Message: "unknown field"})
+			}
+		}
+
+		var file fileRow
+		if err := node.Decode(&file); err != nil {
+			var typeErr *yaml.TypeError
+			if errors.As(err, &typeErr) {
+				for _, msg := range typeErr.Errors {
+					errs = append(errs, models.ImportError{Row: n, Line: line, Message: strings.TrimPrefix(msg, "yaml: ")})
+				}
+			} else {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Message: err.Error()})
+			}
+		}
+
+		rows, rowErrs = appendRow(rows, rowErrs, file, n, line, errs)
+	}
+
+	return rows, rowErrs, nil
+}
+
+// appendRow converts the row as written into the menu row, the row with errors is reported instead
+func appendRow(
+	rows []models.MenuRow,
+	rowErrs []models.ImportError,
+	file fileRow,
+	n, line int,
+	errs []models.ImportError,
+) ([]models.MenuRow, []models.ImportError) {
+	row := models.MenuRow{
+		Row:         n,
+		Line:        line,
+		Kind:        strings.ToLower(file.Kind),
+		Category:    file.Category,
+		Name:        file.Name,
+		Description: file.Description,
+		Price:       file.Price,
+		Diameter:    file.Diameter,
+	}
+
+	switch row.Kind {
+	case models.MenuRowCategory:
+	case models.MenuRowPizza:
+		if file.TypeDough != "" {
+			dough, ok := pizzalndv1.TypeDough_value[strings.ToUpper(file.TypeDough)]
+			if !ok {
+				errs = append(errs, models.ImportError{Row: n, Line: line, Field: "type_dough", Message: "unknown dough " + strconv.Quote(file.TypeDough)})
+			}
+			row.TypeDough = dough
+		}
+	default:
+		errs = append(errs, models.ImportError{Row: n, Line: line, Field: "kind", Message: "must be category or pizza"})
+	}
+
+	if len(errs) != 0 {
+		return rows, append(rowErrs, errs...)
+	}
+
+	return append(rows, row), rowErrs
+}
+
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &SyntaxError{Line: parseErr.Line, Err: parseErr.Err}
+	}
+
+	return &SyntaxError{Err: err}
+}
+
+// lineAt returns the line of the first token at or after the offset
+func lineAt(data []byte, offset int64) int {
+	pos := int(offset)
+	for pos < len(data) && strings.IndexByte(" \t\r\n,", data[pos]) >= 0 {
+		pos++
+	}
+
+	return bytes.Count(data[:pos], []byte{'\n'}) + 1
+}
+
+func isColumn(name string) bool {
+	for _, column := range Columns {
+		if name == column {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package names
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Key returns the form of the name used for the uniqueness checks and lookups.
+// The name is trimmed, has inner whitespace collapsed, is NFKC normalized and Unicode case folded,
+// so "Margherita", " margherita " and "MARGHERITA" share the same key.
+func Key(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+
+	return cases.Fold().String(norm.NFKC.String(name))
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// idleTTL is how long the bucket of a silent client is kept, a returning client starts with the full bucket
+const idleTTL = 10 * time.Minute
+
+// Budget is the token bucket of a single client: Rate tokens per second up to Burst tokens
+type Budget struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter keeps a token bucket per key
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func New() *Limiter {
+	return &Limiter{buckets: make(map[string]*bucket), swept: time.Now()}
+}
+
+// Allow takes a token from the bucket of key, which is created with budget on the first call.
+// When the bucket is empty it returns false and the time until the next token.
+func (l *Limiter) Allow(key string, budget Budget) (bool, time.Duration) {
+	ok, delay, _ := l.Take(key, budget)
+
+	return ok, delay
+}
+
+// Take is Allow which also returns the give back of the taken token,
+// so the calls can be charged only for what turns out to be wrong
+func (l *Limiter) Take(key string, budget Budget) (bool, time.Duration, func()) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(budget.Rate), budget.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		// a zero burst never lets a call through
+		return false, 0, nil
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay, nil
+	}
+
+	// the reservation is cancelled as of the time it was made, later it counts as spent and nothing would come back
+	return true, 0, func() { reservation.CancelAt(now) }
+}
+
+// sweep drops the buckets of the clients silent for idleTTL, at most once per idleTTL
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < idleTTL {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastUsed) >= idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Semaphore caps the number of the concurrent holders
+type Semaphore chan struct{}
+
+func NewSemaphore(size int) Semaphore {
+	return make(Semaphore, size)
+}
+
+// TryAcquire takes a slot without waiting, the slot is returned with Release
+func (s Semaphore) TryAcquire() bool {
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s Semaphore) Release() {
+	<-s
+}
@@ -0,0 +1,174 @@
+package rbac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnyMethod allows every method of the service
+const AnyMethod = "*"
+
+// checkInterval limits how often the policy file is checked for changes, the check runs on the authorization
+const checkInterval = time.Second
+
+// Policy maps the roles to the methods they may call
+type Policy struct {
+	Roles map[string]Role `yaml:"roles"`
+}
+
+type Role struct {
+	// Methods are the bare method names the role may call, "*" allows all of them
+	Methods []string `yaml:"methods"`
+	// Categories limit the calls to the categories with the ids, every category is allowed when it is empty
+	Categories []uint32 `yaml:"categories"`
+}
+
+// Authorize reports whether any of the roles may call the method and the categories the call is limited to.
+// The categories are nil when some role allows the method in every category.
+func (p *Policy) Authorize(roles []string, method string) (bool, map[uint32]bool) {
+	method = path.Base(method)
+
+	var (
+		allowed    bool
+		categories map[uint32]bool
+	)
+	for _, name := range roles {
+		role, ok := p.Roles[name]
+		if !ok || !slices.ContainsFunc(role.Methods, func(m string) bool { return m == method || m == AnyMethod }) {
+			continue
+		}
+
+		if len(role.Categories) == 0 {
+			return true, nil
+		}
+
+		allowed = true
+		if categories == nil {
+			categories = make(map[uint32]bool)
+		}
+		for _, id := range role.Categories {
+			categories[id] = true
+		}
+	}
+
+	return allowed, categories
+}
+
+// LoadPolicy reads the policy file, every method named in it must be one of methods
+func LoadPolicy(file string, methods []string) (*Policy, error) {
+	const op = "rbac.LoadPolicy"
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var policy Policy
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, file, err)
+	}
+
+	if len(policy.Roles) == 0 {
+		return nil, fmt.Errorf("%s: %s: no roles defined", op, file)
+	}
+	for name, role := range policy.Roles {
+		for _, method := range role.Methods {
+			if method != AnyMethod && !slices.Contains(methods, method) {
+				return nil, fmt.Errorf("%s: %s: role %q: unknown method %q", op, file, name, method)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// PolicyFile serves the policy of the file and reloads it when the file changes on disk.
+// A change that fails to load is logged and the previous policy stays in use.
+type PolicyFile struct {
+	log     *slog.Logger
+	file    string
+	methods []string
+
+	mu      sync.Mutex
+	policy  *Policy
+	stamp   string
+	checked time.Time
+}
+
+func NewPolicyFile(log *slog.Logger, file string, methods []string) (*PolicyFile, error) {
+	const op = "rbac.NewPolicyFile"
+
+	if file == "" {
+		return nil, errors.New(op + ": policy file is required")
+	}
+
+	p := &PolicyFile{log: log, file: file, methods: methods}
+
+	stamp, err := p.stampFile()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if p.policy, err = LoadPolicy(file, methods); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	p.stamp, p.checked = stamp, time.Now()
+
+	return p, nil
+}
+
+// Policy returns the current policy, picking up the changes of the file
+func (p *PolicyFile) Policy() *Policy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.checked) < checkInterval {
+		return p.policy
+	}
+	p.checked = time.Now()
+
+	log := p.log.With(slog.String("op", "rbac.PolicyFile"), slog.String("file", p.file))
+
+	stamp, err := p.stampFile()
+	if err != nil {
+		log.Error("failed to check policy file", slog.String("error", err.Error()))
+		return p.policy
+	}
+	if stamp == p.stamp {
+		return p.policy
+	}
+
+	policy, err := LoadPolicy(p.file, p.methods)
+	if err != nil {
+		// the file may be in the middle of being replaced, retry on the next check
+		log.Error("failed to reload policy", slog.String("error", err.Error()))
+		return p.policy
+	}
+
+	p.policy, p.stamp = policy, stamp
+	log.Info("policy reloaded")
+
+	return p.policy
+}
+
+// stampFile changes whenever the file is rewritten
+func (p *PolicyFile) stampFile() (string, error) {
+	info, err := os.Stat(p.file)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano()), nil
+}
@@ -1,18 +1,117 @@
 package reflection
 
 import (
-	"log/slog"
-	"reflect"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func AllFieldsIsNil(msg any) bool {
-	v := reflect.ValueOf(msg).Elem()
-	for i := 0; i < v.NumField(); i++ {
-		f := v.Field(i)
-		slog.Info("Message:", slog.Any("A", f))
-		if !f.IsNil() {
-			return false
+// Change is a single field which differs between two messages.
+// Before or After is nil when the field is not populated on that side.
+type Change struct {
+	Field  string
+	Before any
+	After  any
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Before, c.After)
+}
+
+// Populated returns names of the populated fields of msg in the declaration order.
+// Fields listed in ignore are skipped.
+func Populated(msg proto.Message, ignore ...string) []string {
+	if msg == nil {
+		return nil
+	}
+
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	populated := make([]string, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if m.Has(fd) && !slices.Contains(ignore, string(fd.Name())) {
+			populated = append(populated, string(fd.Name()))
+		}
+	}
+
+	return populated
+}
+
+// IsEmpty reports whether msg has no populated fields besides the ignored ones
+func IsEmpty(msg proto.Message, ignore ...string) bool {
+	return len(Populated(msg, ignore...)) == 0
+}
+
+// Diff returns the fields which differ between two messages of the same type.
+// Fields listed in ignore are skipped.
+func Diff(before, after proto.Message, ignore ...string) []Change {
+	if before == nil || after == nil {
+		return nil
+	}
+
+	b, a := before.ProtoReflect(), after.ProtoReflect()
+	if b.Descriptor().FullName() != a.Descriptor().FullName() {
+		return nil
+	}
+
+	fields := b.Descriptor().Fields()
+
+	var changes []Change
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if slices.Contains(ignore, string(fd.Name())) {
+			continue
+		}
+
+		hasBefore, hasAfter := b.Has(fd), a.Has(fd)
+		if !hasBefore && !hasAfter {
+			continue
+		}
+		if hasBefore && hasAfter && b.Get(fd).Equal(a.Get(fd)) {
+			continue
+		}
+
+		change := Change{Field: string(fd.Name())}
+		if hasBefore {
+			change.Before = value(fd, b.Get(fd))
+		}
+		if hasAfter {
+			change.After = value(fd, a.Get(fd))
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// value converts the field value into a plain Go value suitable for logging and JSON
+func value(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsList(), fd.IsMap():
+		return v.Interface()
+	case fd.Kind() == protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
 		}
+		return int32(v.Enum())
+	case fd.Message() != nil:
+		return unwrap(v.Message())
+	default:
+		return v.Interface()
 	}
-	return true
+}
+
+// unwrap returns the value of the well-known wrapper messages and the message itself otherwise
+func unwrap(m protoreflect.Message) any {
+	if m.Descriptor().FullName().Parent() == "google.protobuf" {
+		if fd := m.Descriptor().Fields().ByName("value"); fd != nil && m.Descriptor().Fields().Len() == 1 {
+			return m.Get(fd).Interface()
+		}
+	}
+
+	return m.Interface()
 }
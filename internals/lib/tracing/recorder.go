@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Recorder keeps every finished span in memory, so tests can assert on the produced traces
+type Recorder struct {
+	*tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+}
+
+// NewRecorder records all spans of the provider it installs as the global one.
+// The previous global provider is not restored.
+func NewRecorder() *Recorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	otel.SetTracerProvider(provider)
+
+	return &Recorder{SpanRecorder: recorder, provider: provider}
+}
+
+// TracerProvider returns the provider the recorder is attached to
+func (r *Recorder) TracerProvider() trace.TracerProvider {
+	return r.provider
+}
+
+// Named returns the finished spans with the name in the order they ended
+func (r *Recorder) Named(name string) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range r.Ended() {
+		if span.Name() == name {
+			spans = append(spans, span)
+		}
+	}
+
+	return spans
+}
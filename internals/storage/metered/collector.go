@@ -0,0 +1,82 @@
+package metered
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout bounds the queries made on scrape
+const collectTimeout = 5 * time.Second
+
+// Source provides the database state exported on scrape
+type Source interface {
+	Stats() sql.DBStats
+	PizzasPerCategory(ctx context.Context) ([]models.CategoryPizzas, error)
+}
+
+// Collector exports the connection pool statistics and the number of pizzas per category
+type Collector struct {
+	source Source
+
+	maxOpen, open, inUse, idle      *prometheus.Desc
+	waitCount, waitDuration         *prometheus.Desc
+	maxIdleClosed, maxLifetimeClose *prometheus.Desc
+	categoryPizzas                  *prometheus.Desc
+}
+
+func NewCollector(source Source) *Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("pizzaland", "", name), help, labels, nil)
+	}
+
+	return &Collector{
+		source:           source,
+		maxOpen:          desc("db_max_open_connections", "Maximum number of open connections to the database."),
+		open:             desc("db_open_connections", "Number of established connections, both in use and idle."),
+		inUse:            desc("db_in_use_connections", "Number of connections currently in use."),
+		idle:             desc("db_idle_connections", "Number of idle connections."),
+		waitCount:        desc("db_wait_count_total", "Number of connections waited for."),
+		waitDuration:     desc("db_wait_duration_seconds_total", "Time blocked waiting for a new connection."),
+		maxIdleClosed:    desc("db_max_idle_closed_total", "Number of connections closed due to the idle limit."),
+		maxLifetimeClose: desc("db_max_lifetime_closed_total", "Number of connections closed due to the lifetime limit."),
+		categoryPizzas:   desc("category_pizzas", "Number of pizzas in the category, the trash excluded.", "category"),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		c.maxOpen, c.open, c.inUse, c.idle,
+		c.waitCount, c.waitDuration, c.maxIdleClosed, c.maxLifetimeClose,
+		c.categoryPizzas,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.source.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeClose, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	counts, err := c.source.PizzasPerCategory(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.categoryPizzas, err)
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.categoryPizzas, prometheus.GaugeValue, float64(count.Pizzas), count.Name)
+	}
+}
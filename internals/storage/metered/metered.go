@@ -0,0 +1,181 @@
+package metered
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/domain/services/pizzaland"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Storage is the storage of the pizza domain
+type Storage interface {
+	pizzaland.Saver
+	pizzaland.Getter
+	pizzaland.Remover
+	pizzaland.Updater
+}
+
+// Metered observes the duration and the failures of every call to the wrapped storage
+type Metered struct {
+	storage   Storage
+	durations *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+}
+
+// New wraps storage, the collectors are registered in reg
+func New(storage Storage, reg prometheus.Registerer) (*Metered, error) {
+	const op = "metered.New"
+
+	m := &Metered{
+		storage: storage,
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "pizzaland",
+			Subsystem: "storage",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of the storage calls.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pizzaland",
+			Subsystem: "storage",
+			Name:      "errors_total",
+			Help:      "Number of the failed storage calls, the not found ones included.",
+		}, []string{"method"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.durations, m.errors} {
+		if err := reg.Register(collector); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metered) observe(method string, start time.Time, err error) {
+	m.durations.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(method).Inc()
+	}
+}
+
+// measure runs fn and observes it as method
+func measure[T any](m *Metered, method string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+
+	result, err := fn()
+
+	m.observe(method, start, err)
+
+	return result, err
+}
+
+func (m *Metered) Save(ctx context.Context, pizza *pizzalndv1.PizzaProperties) (uint64, error) {
+	return measure(m, "Save", func() (uint64, error) { return m.storage.Save(ctx, pizza) })
+}
+
+func (m *Metered) SaveCategory(ctx context.Context, category *pizzalndv1.CategoryProperties) (uint32, error) {
+	return measure(m, "SaveCategory", func() (uint32, error) { return m.storage.SaveCategory(ctx, category) })
+}
+
+func (m *Metered) GetById(ctx context.Context, id uint64) (*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "GetById", func() (*pizzalndv1.PizzaProperties, error) { return m.storage.GetById(ctx, id) })
+}
+
+func (m *Metered) GetByName(ctx context.Context, name string) (*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "GetByName", func() (*pizzalndv1.PizzaProperties, error) { return m.storage.GetByName(ctx, name) })
+}
+
+func (m *Metered) GetCategoryById(ctx context.Context, id uint64) (*pizzalndv1.CategoryProperties, error) {
+	return measure(m, "GetCategoryById", func() (*pizzalndv1.CategoryProperties, error) {
+		return m.storage.GetCategoryById(ctx, id)
+	})
+}
+
+func (m *Metered) GetCategoryByName(ctx context.Context, name string) (*pizzalndv1.CategoryProperties, error) {
+	return measure(m, "GetCategoryByName", func() (*pizzalndv1.CategoryProperties, error) {
+		return m.storage.GetCategoryByName(ctx, name)
+	})
+}
+
+func (m *Metered) List(ctx context.Context, offset uint32, limit uint32) ([]*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "List", func() ([]*pizzalndv1.PizzaProperties, error) { return m.storage.List(ctx, offset, limit) })
+}
+
+func (m *Metered) ListCategory(ctx context.Context, name string, offset uint32, limit uint32) ([]*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "ListCategory", func() ([]*pizzalndv1.PizzaProperties, error) {
+		return m.storage.ListCategory(ctx, name, offset, limit)
+	})
+}
+
+func (m *Metered) ListCategoryById(ctx context.Context, id uint32, offset uint32, limit uint32) ([]*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "ListCategoryById", func() ([]*pizzalndv1.PizzaProperties, error) {
+		return m.storage.ListCategoryById(ctx, id, offset, limit)
+	})
+}
+
+func (m *Metered) ListDeleted(ctx context.Context, offset uint32, limit uint32) ([]*pizzalndv1.PizzaProperties, error) {
+	return measure(m, "ListDeleted", func() ([]*pizzalndv1.PizzaProperties, error) {
+		return m.storage.ListDeleted(ctx, offset, limit)
+	})
+}
+
+func (m *Metered) ListCategories(ctx context.Context, offset uint32, limit uint32) ([]*pizzalndv1.CategoryProperties, error) {
+	return measure(m, "ListCategories", func() ([]*pizzalndv1.CategoryProperties, error) {
+		return m.storage.ListCategories(ctx, offset, limit)
+	})
+}
+
+func (m *Metered) ListDeletedCategories(ctx context.Context, offset uint32, limit uint32) ([]*pizzalndv1.CategoryProperties, error) {
+	return measure(m, "ListDeletedCategories", func() ([]*pizzalndv1.CategoryProperties, error) {
+		return m.storage.ListDeletedCategories(ctx, offset, limit)
+	})
+}
+
+func (m *Metered) RemoveById(ctx context.Context, id uint64, version uint64) (bool, error) {
+	return measure(m, "RemoveById", func() (bool, error) { return m.storage.RemoveById(ctx, id, version) })
+}
+
+func (m *Metered) RemoveByName(ctx context.Context, name string, version uint64) (bool, error) {
+	return measure(m, "RemoveByName", func() (bool, error) { return m.storage.RemoveByName(ctx, name, version) })
+}
+
+func (m *Metered) RemoveCategoryById(ctx context.Context, id uint64, version uint64) (bool, error) {
+	return measure(m, "RemoveCategoryById", func() (bool, error) { return m.storage.RemoveCategoryById(ctx, id, version) })
+}
+
+func (m *Metered) RemoveCategoryByName(ctx context.Context, name string, version uint64) (bool, error) {
+	return measure(m, "RemoveCategoryByName", func() (bool, error) {
+		return m.storage.RemoveCategoryByName(ctx, name, version)
+	})
+}
+
+func (m *Metered) Purge(ctx context.Context, before time.Time) (pizzas, categories int64, err error) {
+	start := time.Now()
+
+	pizzas, categories, err = m.storage.Purge(ctx, before)
+
+	m.observe("Purge", start, err)
+
+	return pizzas, categories, err
+}
+
+func (m *Metered) Update(ctx context.Context, id uint64, version uint64, update models.PizzaUpdate) (bool, error) {
+	return measure(m, "Update", func() (bool, error) { return m.storage.Update(ctx, id, version, update) })
+}
+
+func (m *Metered) UpdateCategory(ctx context.Context, id uint32, version uint64, update models.CategoryUpdate) (bool, error) {
+	return measure(m, "UpdateCategory", func() (bool, error) { return m.storage.UpdateCategory(ctx, id, version, update) })
+}
+
+func (m *Metered) Restore(ctx context.Context, id uint64) (bool, error) {
+	return measure(m, "Restore", func() (bool, error) { return m.storage.Restore(ctx, id) })
+}
+
+func (m *Metered) RestoreCategory(ctx context.Context, id uint32) (bool, error) {
+	return measure(m, "RestoreCategory", func() (bool, error) { return m.storage.RestoreCategory(ctx, id) })
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/storage"
+)
+
+const apiKeyColumns = "id, prefix, secret_hash, label, scopes, created_at, expires_at, last_used_at, revoked_at"
+
+// SaveAPIKey stores the key, the scopes are kept space separated
+func (s *Storage) SaveAPIKey(ctx context.Context, key models.APIKey) (id uint64, err error) {
+	const op = "storage.sqlite.SaveAPIKey"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO api_keys (prefix, secret_hash, label, scopes, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		key.Prefix, key.SecretHash, key.Label, strings.Join(key.Scopes, " "), key.CreatedAt.UTC(), nullTime(key.ExpiresAt),
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrAPIKeyExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lastId, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint64(lastId), nil
+}
+
+// APIKeyByPrefix returns the key with the prefix, the revoked and expired ones included
+func (s *Storage) APIKeyByPrefix(ctx context.Context, prefix string) (key models.APIKey, err error) {
+	const op = "storage.sqlite.APIKeyByPrefix"
+
+	key, err = scanAPIKey(s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = ?`,
+		prefix,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIKey{}, fmt.Errorf("%s: %w", op, storage.ErrAPIKeyNotFound)
+		}
+
+		return models.APIKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (s *Storage) ListAPIKeys(ctx context.Context, offset uint32, limit uint32) (keys []models.APIKey, err error) {
+	const op = "storage.sqlite.ListAPIKeys"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys ORDER BY id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks the key revoked, the already revoked keys are not found
+func (s *Storage) RevokeAPIKey(ctx context.Context, id uint64, at time.Time) error {
+	const op = "storage.sqlite.RevokeAPIKey"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`,
+		at.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// TouchAPIKey records the last use of the key
+func (s *Storage) TouchAPIKey(ctx context.Context, id uint64, at time.Time) error {
+	const op = "storage.sqlite.TouchAPIKey"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE api_keys SET last_used_at = ? WHERE id = ?`,
+		at.UTC(), id,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row scanner) (models.APIKey, error) {
+	var (
+		key                              models.APIKey
+		scopes                           string
+		expiresAt, lastUsedAt, revokedAt sql.NullTime
+	)
+
+	if err := row.Scan(
+		&key.ID, &key.Prefix, &key.SecretHash, &key.Label, &scopes,
+		&key.CreatedAt, &expiresAt, &lastUsedAt, &revokedAt,
+	); err != nil {
+		return models.APIKey{}, err
+	}
+
+	key.Scopes = strings.Fields(scopes)
+	key.ExpiresAt, key.LastUsedAt, key.RevokedAt = expiresAt.Time, lastUsedAt.Time, revokedAt.Time
+
+	return key, nil
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.UTC()
+}
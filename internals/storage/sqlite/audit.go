@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+const auditColumns = "id, occurred_at, actor, request_id, operation, entity, entity_id, before, after"
+
+// SaveAuditEvent appends the event to the audit log, call it in the transaction of the change
+func (s *Storage) SaveAuditEvent(ctx context.Context, event models.AuditEvent) (id uint64, err error) {
+	const op = "storage.sqlite.SaveAuditEvent"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO audit_log (occurred_at, actor, request_id, operation, entity, entity_id, before, after)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.OccurredAt.UTC(), event.Actor, event.RequestId, event.Operation, event.Entity, event.EntityId,
+		nullable(event.Before), nullable(event.After),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lastId, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint64(lastId), nil
+}
+
+// LastAuditEventId returns the id of the latest audit event, zero when the log is empty
+func (s *Storage) LastAuditEventId(ctx context.Context) (id uint64, err error) {
+	const op = "storage.sqlite.LastAuditEventId"
+
+	if err := s.conn(ctx).QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM audit_log`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ListAuditEvents returns the events matching the filter, the newest first
+func (s *Storage) ListAuditEvents(ctx context.Context, filter models.AuditFilter, offset uint32, limit uint32) (events []models.AuditEvent, err error) {
+	const op = "storage.sqlite.ListAuditEvents"
+
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, value any) {
+		conditions = append(conditions, condition)
+		args = append(args, value)
+	}
+
+	if filter.Entity != "" {
+		where("entity = ?", filter.Entity)
+	}
+	if filter.Actor != "" {
+		where("actor = ?", filter.Actor)
+	}
+	if !filter.From.IsZero() {
+		where("occurred_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		where("occurred_at < ?", filter.To.UTC())
+	}
+
+	query := `SELECT ` + auditColumns + ` FROM audit_log`
+	if len(conditions) != 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event         models.AuditEvent
+			before, after sql.NullString
+		)
+		if err := rows.Scan(
+			&event.ID, &event.OccurredAt, &event.Actor, &event.RequestId, &event.Operation,
+			&event.Entity, &event.EntityId, &before, &after,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		event.Before, event.After = before.String, after.String
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,22 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/nhassl3/pizzaland/internals/lib/names"
+)
+
+// DriverName is the sqlite3 driver with the pizzaland SQL functions registered on every connection,
+// which also traces every statement.
+// Migrations rely on these functions, so open the database with it in the migrator too.
+const DriverName = "sqlite3_pizzaland"
+
+func init() {
+	sql.Register(DriverName, tracedDriver{&sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// name_key(name) is the normalized name used by the unique name indexes, see names.Key
+			return conn.RegisterFunc("name_key", names.Key, true)
+		},
+	}})
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/nhassl3/pizzaland/internals/lib/names"
+)
+
+// NameConflict is a group of live rows whose names share the same names.Key
+type NameConflict struct {
+	Table string
+	Key   string
+	Ids   []int64
+	Names []string
+}
+
+func (c NameConflict) String() string {
+	return fmt.Sprintf("%s: %q is used by ids %v as %q", c.Table, c.Key, c.Ids, c.Names)
+}
+
+// FindDuplicateNames reports the pizzas and categories which would break the unique name indexes.
+// It works on any schema version, so the migrator may run it before the indexes are created.
+func FindDuplicateNames(ctx context.Context, db *sql.DB) ([]NameConflict, error) {
+	const op = "storage.sqlite.FindDuplicateNames"
+
+	var conflicts []NameConflict
+	for _, table := range []string{"pizza", "categories"} {
+		found, err := duplicateNames(ctx, db, table)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		conflicts = append(conflicts, found...)
+	}
+
+	return conflicts, nil
+}
+
+func duplicateNames(ctx context.Context, db *sql.DB, table string) ([]NameConflict, error) {
+	var tables int
+	if err := db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+		table,
+	).Scan(&tables); err != nil {
+		return nil, err
+	}
+	if tables == 0 {
+		return nil, nil
+	}
+
+	// rows in the trash do not take part in the unique indexes
+	var softDelete int
+	if err := db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'deleted_at'`,
+		table,
+	).Scan(&softDelete); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, name FROM ` + table
+	if softDelete != 0 {
+		query += ` WHERE deleted_at IS NULL`
+	}
+	query += ` ORDER BY id`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make(map[string]*NameConflict)
+	for rows.Next() {
+		var (
+			id   int64
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+
+		key := names.Key(name)
+		group, ok := groups[key]
+		if !ok {
+			group = &NameConflict{Table: table, Key: key}
+			groups[key] = group
+		}
+		group.Ids = append(group.Ids, id)
+		group.Names = append(group.Names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var conflicts []NameConflict
+	for _, group := range groups {
+		if len(group.Ids) > 1 {
+			conflicts = append(conflicts, *group)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Ids[0] < conflicts[j].Ids[0] })
+
+	return conflicts, nil
+}
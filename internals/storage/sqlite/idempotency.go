@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/storage"
+)
+
+// IdempotencyKey returns the not yet expired record of the key
+func (s *Storage) IdempotencyKey(ctx context.Context, scope, key string) (record models.IdempotencyKey, err error) {
+	const op = "storage.sqlite.IdempotencyKey"
+
+	var expiresAt int64
+	err = s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT scope, key, request_hash, response, expires_at FROM idempotency_keys
+		WHERE scope = ? AND key = ? AND expires_at > ?`,
+		scope, key, time.Now().Unix(),
+	).Scan(&record.Scope, &record.Key, &record.RequestHash, &record.Response, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, storage.ErrIdempotencyKeyNotFound)
+		}
+
+		return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record.ExpiresAt = time.Unix(expiresAt, 0)
+
+	return record, nil
+}
+
+// SaveIdempotencyKey stores the record, dropping the expired keys on the way
+func (s *Storage) SaveIdempotencyKey(ctx context.Context, record models.IdempotencyKey) error {
+	const op = "storage.sqlite.SaveIdempotencyKey"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`DELETE FROM idempotency_keys WHERE expires_at <= ?`,
+		time.Now().Unix(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO idempotency_keys (scope, key, request_hash, response, expires_at) VALUES (?, ?, ?, ?, ?)`,
+		record.Scope, record.Key, record.RequestHash, record.Response, record.ExpiresAt.Unix(),
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+			return fmt.Errorf("%s: %w", op, storage.ErrIdempotencyKeyExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+const outboxColumns = "id, event_id, type, source, subject, time, data, attempts, next_attempt_at, last_error"
+
+// SaveOutboxEvent queues the event for the delivery, call it in the transaction of the change
+func (s *Storage) SaveOutboxEvent(ctx context.Context, event models.Event) error {
+	const op = "storage.sqlite.SaveOutboxEvent"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO outbox (event_id, type, source, subject, time, data, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		event.ID, event.Type, event.Source, event.Subject, event.Time.UTC(), string(event.Data), event.Time.UTC(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// OutboxEvents returns the first queued events in the order they were written, due or not
+func (s *Storage) OutboxEvents(ctx context.Context, limit int) (events []models.OutboxEvent, err error) {
+	const op = "storage.sqlite.OutboxEvents"
+
+	rows, err := s.conn(ctx).QueryContext(ctx, `SELECT `+outboxColumns+` FROM outbox ORDER BY id LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event     models.OutboxEvent
+			data      string
+			lastError sql.NullString
+		)
+		if err := rows.Scan(
+			&event.Seq, &event.Event.ID, &event.Event.Type, &event.Event.Source, &event.Event.Subject,
+			&event.Event.Time, &data, &event.Attempts, &event.NextAttemptAt, &lastError,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		event.Event.SpecVersion, event.Event.DataContentType = models.EventSpecVersion, "application/json"
+		event.Event.Data, event.LastError = []byte(data), lastError.String
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// DeleteOutboxEvent removes the delivered event from the queue
+func (s *Storage) DeleteOutboxEvent(ctx context.Context, seq uint64) error {
+	const op = "storage.sqlite.DeleteOutboxEvent"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, `DELETE FROM outbox WHERE id = ?`, seq); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RetryOutboxEvent records the failed attempt and postpones the next one
+func (s *Storage) RetryOutboxEvent(ctx context.Context, seq uint64, nextAttemptAt time.Time, lastError string) error {
+	const op = "storage.sqlite.RetryOutboxEvent"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?`,
+		nextAttemptAt.UTC(), lastError, seq,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeadLetterOutboxEvent moves the event which failed its last attempt from the queue to the dead letters
+func (s *Storage) DeadLetterOutboxEvent(ctx context.Context, seq uint64, lastError string, at time.Time) error {
+	const op = "storage.sqlite.DeadLetterOutboxEvent"
+
+	err := s.InTx(ctx, func(ctx context.Context) error {
+		if _, err := s.conn(ctx).ExecContext(
+			ctx,
+			`INSERT INTO dead_letters (event_id, type, source, subject, time, data, attempts, last_error, failed_at)
+			SELECT event_id, type, source, subject, time, data, attempts + 1, ?, ? FROM outbox WHERE id = ?`,
+			lastError, at.UTC(), seq,
+		); err != nil {
+			return err
+		}
+
+		_, err := s.conn(ctx).ExecContext(ctx, `DELETE FROM outbox WHERE id = ?`, seq)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
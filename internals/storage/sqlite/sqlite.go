@@ -3,17 +3,49 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/lib/names"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+const (
+	pizzaColumns    = "p.id, p.category_id, p.name, p.description, p.type_dough, p.price, p.diameter, p.deleted_at, p.version"
+	categoryColumns = "c.id, c.name, c.description, c.deleted_at, c.version"
+
+	pizzaVersionById      = `SELECT version FROM pizza WHERE id = ? AND deleted_at IS NULL`
+	pizzaVersionByName    = `SELECT version FROM pizza WHERE name_key = ? AND deleted_at IS NULL`
+	categoryVersionById   = `SELECT version FROM categories WHERE id = ? AND deleted_at IS NULL`
+	categoryVersionByName = `SELECT version FROM categories WHERE name_key = ? AND deleted_at IS NULL`
+	versionCondition      = ` AND version = ?`
+
+	// liveCategory makes the pizza write conditional on its category being out of the trash
+	liveCategory = `EXISTS (SELECT 1 FROM categories WHERE id = ? AND deleted_at IS NULL)`
+)
+
+// connParams make the transactions take the write lock when they begin, so two of them never deadlock
+// upgrading their read locks, and make the connections wait for the lock instead of failing right away
+const connParams = "_txlock=immediate&_busy_timeout=5000"
+
 type Storage struct {
 	db *sql.DB
 }
 
 func NewStorage(path string) (*Storage, error) {
-	db, err := sql.Open("sqlite3", path)
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	db, err := sql.Open(DriverName, path+sep+connParams)
 	if err != nil {
 		return nil, err
 	}
@@ -21,66 +53,741 @@ func NewStorage(path string) (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
+// Close closes the database, the storage must not be used afterwards
+func (s *Storage) Close() error {
+	const op = "storage.sqlite.Close"
+
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Ping reads the schema, so a broken database file is noticed too. The driver creates the missing file empty,
+// so the catalog tables are looked for.
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "storage.sqlite.Ping"
+
+	var tables int
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('pizza', 'categories')`,
+	).Scan(&tables)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tables != 2 {
+		return fmt.Errorf("%s: %w", op, storage.ErrNotMigrated)
+	}
+
+	return nil
+}
+
+// Save inserts the pizza into its category, ErrCategoryNotFound is returned when the category is missing or in the trash
 func (s *Storage) Save(ctx context.Context, pizza *pizzalndv1.PizzaProperties) (pizzaId uint64, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.Save"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO pizza (category_id, name, name_key, description, type_dough, price, diameter)
+		SELECT ?, ?, ?, ?, ?, ?, ? WHERE `+liveCategory,
+		pizza.GetCategoryId(),
+		pizza.GetName(),
+		names.Key(pizza.GetName()),
+		nullString(pizza.GetDescription()),
+		int32(pizza.GetTypeDough()),
+		pizza.GetPrice(),
+		pizza.GetDiameter(),
+		pizza.GetCategoryId(),
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrPizzaExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := affected(op, res, storage.ErrCategoryNotFound); err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint64(id), nil
 }
 
 func (s *Storage) SaveCategory(ctx context.Context, category *pizzalndv1.CategoryProperties) (categoryId uint32, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.SaveCategory"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO categories (name, name_key, description) VALUES (?, ?, ?)`,
+		category.GetName(),
+		names.Key(category.GetName()),
+		nullString(category.GetDescription()),
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrCategoryExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint32(id), nil
 }
 
 func (s *Storage) GetById(ctx context.Context, id uint64) (pizza *pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.GetById"
+
+	row := s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p WHERE p.id = ? AND p.deleted_at IS NULL`,
+		id,
+	)
+
+	pizza, err = scanPizza(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrPizzaNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
 }
 
 func (s *Storage) GetByName(ctx context.Context, name string) (pizza *pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.GetByName"
+
+	row := s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p WHERE p.name_key = ? AND p.deleted_at IS NULL`,
+		names.Key(name),
+	)
+
+	pizza, err = scanPizza(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrPizzaNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
 }
 
 func (s *Storage) GetCategoryById(ctx context.Context, id uint64) (category *pizzalndv1.CategoryProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.GetCategoryById"
+
+	row := s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT `+categoryColumns+` FROM categories c WHERE c.id = ? AND c.deleted_at IS NULL`,
+		id,
+	)
+
+	category, err = scanCategory(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrCategoryNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return category, nil
 }
 
 func (s *Storage) GetCategoryByName(ctx context.Context, name string) (category *pizzalndv1.CategoryProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.GetCategoryByName"
+
+	row := s.conn(ctx).QueryRowContext(
+		ctx,
+		`SELECT `+categoryColumns+` FROM categories c WHERE c.name_key = ? AND c.deleted_at IS NULL`,
+		names.Key(name),
+	)
+
+	category, err = scanCategory(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrCategoryNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return category, nil
 }
 
 func (s *Storage) List(ctx context.Context, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.List"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p
+		JOIN categories c ON c.id = p.category_id
+		WHERE p.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY p.id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pizza, err = scanPizzas(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
 }
 
 func (s *Storage) ListCategory(ctx context.Context, name string, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
-	panic("implement me")
+	const op = "storage.sqlite.ListCategory"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p
+		JOIN categories c ON c.id = p.category_id
+		WHERE c.name_key = ? AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY p.id LIMIT ? OFFSET ?`,
+		names.Key(name), limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pizza, err = scanPizzas(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
+}
+
+// ListCategoryById returns the live pizzas of the live category with the id
+func (s *Storage) ListCategoryById(ctx context.Context, id uint32, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
+	const op = "storage.sqlite.ListCategoryById"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p
+		JOIN categories c ON c.id = p.category_id
+		WHERE c.id = ? AND p.deleted_at IS NULL AND c.deleted_at IS NULL
+		ORDER BY p.id LIMIT ? OFFSET ?`,
+		id, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pizza, err = scanPizzas(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
+}
+
+// ListDeleted returns pizzas in the trash, most recently removed first
+func (s *Storage) ListDeleted(ctx context.Context, offset uint32, limit uint32) (pizza []*pizzalndv1.PizzaProperties, err error) {
+	const op = "storage.sqlite.ListDeleted"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+pizzaColumns+` FROM pizza p
+		WHERE p.deleted_at IS NOT NULL
+		ORDER BY p.deleted_at DESC, p.id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pizza, err = scanPizzas(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizza, nil
+}
+
+// ListCategories returns the live categories ordered by id
+func (s *Storage) ListCategories(ctx context.Context, offset uint32, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error) {
+	const op = "storage.sqlite.ListCategories"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+categoryColumns+` FROM categories c
+		WHERE c.deleted_at IS NULL
+		ORDER BY c.id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	categories = make([]*pizzalndv1.CategoryProperties, 0, limit)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+// ListDeletedCategories returns categories in the trash, most recently removed first
+func (s *Storage) ListDeletedCategories(ctx context.Context, offset uint32, limit uint32) (categories []*pizzalndv1.CategoryProperties, err error) {
+	const op = "storage.sqlite.ListDeletedCategories"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+categoryColumns+` FROM categories c
+		WHERE c.deleted_at IS NOT NULL
+		ORDER BY c.deleted_at DESC, c.id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	categories = make([]*pizzalndv1.CategoryProperties, 0, limit)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return categories, nil
+}
+
+func (s *Storage) RemoveById(ctx context.Context, id uint64, version uint64) (success bool, err error) {
+	const op = "storage.sqlite.RemoveById"
+
+	query, args := `UPDATE pizza SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL`, []any{id}
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applied(ctx, op, res, version, pizzaVersionById, id, storage.ErrPizzaNotFound)
+}
+
+func (s *Storage) RemoveByName(ctx context.Context, name string, version uint64) (success bool, err error) {
+	const op = "storage.sqlite.RemoveByName"
+
+	query, args := `UPDATE pizza SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE name_key = ? AND deleted_at IS NULL`, []any{names.Key(name)}
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applied(ctx, op, res, version, pizzaVersionByName, names.Key(name), storage.ErrPizzaNotFound)
 }
 
-func (s *Storage) RemoveById(ctx context.Context, id uint64) (success bool, err error) {
-	panic("implement me")
+func (s *Storage) RemoveCategoryById(ctx context.Context, id uint64, version uint64) (success bool, err error) {
+	const op = "storage.sqlite.RemoveCategoryById"
+
+	query, args := `UPDATE categories SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ? AND deleted_at IS NULL`, []any{id}
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applied(ctx, op, res, version, categoryVersionById, id, storage.ErrCategoryNotFound)
 }
 
-func (s *Storage) RemoveByName(ctx context.Context, name string) (success bool, err error) {
-	panic("implement me")
+func (s *Storage) RemoveCategoryByName(ctx context.Context, name string, version uint64) (success bool, err error) {
+	const op = "storage.sqlite.RemoveCategoryByName"
+
+	query, args := `UPDATE categories SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE name_key = ? AND deleted_at IS NULL`, []any{names.Key(name)}
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applied(ctx, op, res, version, categoryVersionByName, names.Key(name), storage.ErrCategoryNotFound)
 }
 
-func (s *Storage) RemoveCategoryById(ctx context.Context, id uint64) (success bool, err error) {
-	panic("implement me")
+// Purge hard-deletes pizzas and categories which were moved to the trash before the given time.
+// Categories still referenced by any pizza are kept.
+func (s *Storage) Purge(ctx context.Context, before time.Time) (pizzas, categories int64, err error) {
+	const op = "storage.sqlite.Purge"
+
+	err = s.InTx(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(
+			ctx,
+			`DELETE FROM pizza WHERE deleted_at IS NOT NULL AND deleted_at < datetime(?, 'unixepoch')`,
+			before.Unix(),
+		)
+		if err != nil {
+			return err
+		}
+		if pizzas, err = res.RowsAffected(); err != nil {
+			return err
+		}
+
+		res, err = s.conn(ctx).ExecContext(
+			ctx,
+			`DELETE FROM categories
+			WHERE deleted_at IS NOT NULL AND deleted_at < datetime(?, 'unixepoch')
+			AND NOT EXISTS (SELECT 1 FROM pizza p WHERE p.category_id = categories.id)`,
+			before.Unix(),
+		)
+		if err != nil {
+			return err
+		}
+		categories, err = res.RowsAffected()
+
+		return err
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pizzas, categories, nil
 }
 
-func (s *Storage) RemoveCategoryByName(ctx context.Context, name string) (success bool, err error) {
-	panic("implement me")
+// Update writes the non-nil fields of the update and bumps the pizza version.
+// Non-zero version makes the write conditional on the current pizza version.
+// The pizza is moved only into a live category, ErrCategoryNotFound is returned otherwise.
+func (s *Storage) Update(ctx context.Context, id uint64, version uint64, update models.PizzaUpdate) (success bool, err error) {
+	const op = "storage.sqlite.Update"
+
+	var (
+		sets []string
+		args []any
+	)
+	set := func(column string, value any) {
+		sets = append(sets, column+" = ?")
+		args = append(args, value)
+	}
+
+	if update.CategoryId != nil {
+		set("category_id", *update.CategoryId)
+	}
+	if update.Name != nil {
+		set("name", *update.Name)
+		set("name_key", names.Key(*update.Name))
+	}
+	if update.Description != nil {
+		set("description", nullable(*update.Description))
+	}
+	if update.TypeDough != nil {
+		set("type_dough", *update.TypeDough)
+	}
+	if update.Price != nil {
+		set("price", *update.Price)
+	}
+	if update.Diameter != nil {
+		set("diameter", *update.Diameter)
+	}
+	sets = append(sets, "version = version + 1")
+
+	query := `UPDATE pizza SET ` + strings.Join(sets, ", ") + ` WHERE id = ? AND deleted_at IS NULL`
+	args = append(args, id)
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+	if update.CategoryId != nil {
+		query, args = query+` AND `+liveCategory, append(args, *update.CategoryId)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrPizzaExists)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 && update.CategoryId != nil {
+		var current uint64
+		err := s.conn(ctx).QueryRowContext(ctx, categoryVersionById, *update.CategoryId).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrCategoryNotFound)
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return s.applied(ctx, op, res, version, pizzaVersionById, id, storage.ErrPizzaNotFound)
 }
 
-func (s *Storage) Update(
-	ctx context.Context,
-	categoryId uint32,
-	name string,
-	description string,
-	typeDough pizzalndv1.TypeDough,
-	price float64,
-	diameter uint32,
-) (success bool, err error) {
-	panic("implement me")
+// UpdateCategory writes the non-nil fields of the update and bumps the category version.
+// Non-zero version makes the write conditional on the current category version.
+func (s *Storage) UpdateCategory(ctx context.Context, id uint32, version uint64, update models.CategoryUpdate) (success bool, err error) {
+	const op = "storage.sqlite.UpdateCategory"
+
+	var (
+		sets []string
+		args []any
+	)
+
+	if update.Name != nil {
+		sets, args = append(sets, "name = ?", "name_key = ?"), append(args, *update.Name, names.Key(*update.Name))
+	}
+	if update.Description != nil {
+		sets, args = append(sets, "description = ?"), append(args, nullable(*update.Description))
+	}
+	sets = append(sets, "version = version + 1")
+
+	query := `UPDATE categories SET ` + strings.Join(sets, ", ") + ` WHERE id = ? AND deleted_at IS NULL`
+	args = append(args, id)
+	if version != 0 {
+		query, args = query+versionCondition, append(args, version)
+	}
+
+	res, err := s.conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrCategoryExists)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.applied(ctx, op, res, version, categoryVersionById, id, storage.ErrCategoryNotFound)
 }
 
-func (s *Storage) UpdateCategory(ctx context.Context, name string, descriptions string) (success bool, err error) {
-	panic("implement me")
+// Restore takes the pizza out of the trash, ErrCategoryNotFound is returned while its category stays there
+func (s *Storage) Restore(ctx context.Context, id uint64) (success bool, err error) {
+	const op = "storage.sqlite.Restore"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE pizza SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL
+		AND EXISTS (SELECT 1 FROM categories c WHERE c.id = pizza.category_id AND c.deleted_at IS NULL)`,
+		id,
+	)
+	if err != nil {
+		// a live row has taken the name while this one was in the trash
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrPizzaExists)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		var trashed int
+		err := s.conn(ctx).QueryRowContext(ctx, `SELECT 1 FROM pizza WHERE id = ? AND deleted_at IS NOT NULL`, id).Scan(&trashed)
+		if err == nil {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrCategoryNotFound)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return affected(op, res, storage.ErrPizzaNotFound)
+}
+
+func (s *Storage) RestoreCategory(ctx context.Context, id uint32) (success bool, err error) {
+	const op = "storage.sqlite.RestoreCategory"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE categories SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`,
+		id,
+	)
+	if err != nil {
+		// a live row has taken the name while this one was in the trash
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrCategoryExists)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected(op, res, storage.ErrCategoryNotFound)
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPizza(row scanner) (*pizzalndv1.PizzaProperties, error) {
+	var (
+		id          uint64
+		categoryId  uint32
+		name        string
+		description sql.NullString
+		typeDough   int32
+		price       float32
+		diameter    sql.NullInt64
+		deletedAt   sql.NullTime
+		version     uint64
+	)
+
+	if err := row.Scan(&id, &categoryId, &name, &description, &typeDough, &price, &diameter, &deletedAt, &version); err != nil {
+		return nil, err
+	}
+
+	pizza := &pizzalndv1.PizzaProperties{
+		PizzaId:    wrapperspb.UInt64(id),
+		CategoryId: categoryId,
+		Name:       name,
+		TypeDough:  pizzalndv1.TypeDough(typeDough),
+		Price:      price,
+		Diameter:   uint32(diameter.Int64),
+		Version:    version,
+	}
+	if description.Valid {
+		pizza.Description = wrapperspb.String(description.String)
+	}
+	if deletedAt.Valid {
+		pizza.DeletedAt = timestamppb.New(deletedAt.Time)
+	}
+
+	return pizza, nil
+}
+
+func scanPizzas(rows *sql.Rows) ([]*pizzalndv1.PizzaProperties, error) {
+	defer rows.Close()
+
+	var pizza []*pizzalndv1.PizzaProperties
+	for rows.Next() {
+		p, err := scanPizza(rows)
+		if err != nil {
+			return nil, err
+		}
+		pizza = append(pizza, p)
+	}
+
+	return pizza, rows.Err()
+}
+
+func scanCategory(row scanner) (*pizzalndv1.CategoryProperties, error) {
+	var (
+		id          uint32
+		name        string
+		description sql.NullString
+		deletedAt   sql.NullTime
+		version     uint64
+	)
+
+	if err := row.Scan(&id, &name, &description, &deletedAt, &version); err != nil {
+		return nil, err
+	}
+
+	category := &pizzalndv1.CategoryProperties{
+		CategoryId: wrapperspb.UInt32(id),
+		Name:       name,
+		Version:    version,
+	}
+	if description.Valid {
+		category.Description = wrapperspb.String(description.String)
+	}
+	if deletedAt.Valid {
+		category.DeletedAt = timestamppb.New(deletedAt.Time)
+	}
+
+	return category, nil
+}
+
+// affected reports whether the statement touched any row and returns notFound otherwise
+func affected(op string, res sql.Result, notFound error) (bool, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n == 0 {
+		return false, fmt.Errorf("%s: %w", op, notFound)
+	}
+
+	return true, nil
+}
+
+// applied checks the result of the statement conditional on the version.
+// When nothing was touched it tells apart a missing row and a stale version using the lookup query.
+func (s *Storage) applied(ctx context.Context, op string, res sql.Result, version uint64, lookup string, arg any, notFound error) (bool, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n > 0 {
+		return true, nil
+	}
+
+	if version == 0 {
+		return false, fmt.Errorf("%s: %w", op, notFound)
+	}
+
+	var current uint64
+	if err := s.conn(ctx).QueryRowContext(ctx, lookup, arg).Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("%s: %w", op, notFound)
+		}
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return false, fmt.Errorf("%s: %w", op, storage.ErrVersionMismatch)
+}
+
+// nullable stores the empty string as NULL
+func nullable(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+func nullString(v *wrapperspb.StringValue) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: v.GetValue(), Valid: true}
 }
@@ -0,0 +1,120 @@
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/storage"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+)
+
+func TestPizzaCategoryMustBeLive(t *testing.T) {
+	s := sqlitetest.New(t)
+	ctx := context.Background()
+
+	classic := saveCategory(t, s, "Classic")
+	trashed := saveCategory(t, s, "Seasonal")
+
+	id, err := s.Save(ctx, pizza("Margherita", classic))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.RemoveCategoryById(ctx, uint64(trashed), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, category := range map[string]uint32{"missing": 999, "trashed": trashed} {
+		if _, err := s.Save(ctx, pizza("Pepperoni", category)); !errors.Is(err, storage.ErrCategoryNotFound) {
+			t.Errorf("save into the %s category: %v, want ErrCategoryNotFound", name, err)
+		}
+
+		if _, err := s.Update(ctx, id, 0, models.PizzaUpdate{CategoryId: &category}); !errors.Is(err, storage.ErrCategoryNotFound) {
+			t.Errorf("move into the %s category: %v, want ErrCategoryNotFound", name, err)
+		}
+	}
+
+	pizzas, err := s.List(ctx, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pizzas) != 1 || pizzas[0].GetCategoryId() != classic {
+		t.Fatalf("pizzas %v, want only Margherita in Classic", pizzas)
+	}
+
+	// the pizza of the trashed category stays in the trash until the category is restored
+	if _, err := s.RemoveById(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RemoveCategoryById(ctx, uint64(classic), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Restore(ctx, id); !errors.Is(err, storage.ErrCategoryNotFound) {
+		t.Fatalf("restore into the trashed category: %v, want ErrCategoryNotFound", err)
+	}
+	if _, err := s.Restore(ctx, 999); !errors.Is(err, storage.ErrPizzaNotFound) {
+		t.Errorf("restore of the missing pizza: %v, want ErrPizzaNotFound", err)
+	}
+
+	if _, err := s.RestoreCategory(ctx, classic); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Restore(ctx, id); err != nil {
+		t.Fatalf("restore after the category: %v", err)
+	}
+}
+
+func TestListCategoryById(t *testing.T) {
+	s := sqlitetest.New(t)
+	ctx := context.Background()
+
+	classic := saveCategory(t, s, "Classic")
+	seasonal := saveCategory(t, s, "Seasonal")
+
+	for _, p := range []*pizzalndv1.PizzaProperties{pizza("Margherita", classic), pizza("Pumpkin", seasonal), pizza("Pepperoni", classic)} {
+		if _, err := s.Save(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pizzas, err := s.ListCategoryById(ctx, classic, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pizzas) != 2 || pizzas[0].GetName() != "Margherita" || pizzas[1].GetName() != "Pepperoni" {
+		t.Errorf("pizzas %v, want Margherita and Pepperoni", pizzas)
+	}
+
+	if _, err := s.RemoveCategoryById(ctx, uint64(seasonal), 0); err != nil {
+		t.Fatal(err)
+	}
+	if pizzas, err := s.ListCategoryById(ctx, seasonal, 0, 10); err != nil || len(pizzas) != 0 {
+		t.Errorf("pizzas of the trashed category %v, %v, want none", pizzas, err)
+	}
+}
+
+func saveCategory(t *testing.T, s *sqlite.Storage, name string) uint32 {
+	t.Helper()
+
+	id, err := s.SaveCategory(context.Background(), &pizzalndv1.CategoryProperties{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func pizza(name string, categoryId uint32) *pizzalndv1.PizzaProperties {
+	return &pizzalndv1.PizzaProperties{
+		CategoryId: categoryId,
+		Name:       name,
+		TypeDough:  pizzalndv1.TypeDough_THIN_DOUGH,
+		Price:      9.5,
+		Diameter:   30,
+	}
+}
@@ -0,0 +1,62 @@
+// Package sqlitetest opens the migrated storage in a temporary directory for the tests
+package sqlitetest
+
+import (
+	"database/sql"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	sqlite3migrate "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite"
+)
+
+// New returns the storage with all the migrations of the repository applied, it is closed with the test
+func New(t testing.TB) *sqlite.Storage {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "pizzaland.db")
+
+	// the migrations use the SQL functions registered by the application driver
+	db, err := sql.Open(sqlite.DriverName, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	driver, err := sqlite3migrate.WithInstance(db, &sqlite3migrate.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://"+migrationsPath(), "sqlite3", driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if err, _ := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := sqlite.NewStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := storage.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return storage
+}
+
+// migrationsPath is the migrations directory at the root of the repository
+func migrationsPath() string {
+	_, file, _, _ := runtime.Caller(0)
+
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrations")
+}
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+)
+
+// Stats returns the connection pool statistics of the database
+func (s *Storage) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// PizzasPerCategory counts the live pizzas of every live category, the empty categories included
+func (s *Storage) PizzasPerCategory(ctx context.Context) ([]models.CategoryPizzas, error) {
+	const op = "storage.sqlite.PizzasPerCategory"
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT c.name, COUNT(p.id) FROM categories c
+		LEFT JOIN pizza p ON p.category_id = c.id AND p.deleted_at IS NULL
+		WHERE c.deleted_at IS NULL
+		GROUP BY c.id
+		ORDER BY c.id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var counts []models.CategoryPizzas
+	for rows.Next() {
+		var count models.CategoryPizzas
+		if err := rows.Scan(&count.Name, &count.Pizzas); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
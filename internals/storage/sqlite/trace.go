@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/nhassl3/pizzaland/internals/lib/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/nhassl3/pizzaland/internals/storage/sqlite"
+
+// tracedDriver opens the connections which trace every statement run with a context
+type tracedDriver struct {
+	*sqlite3.SQLiteDriver
+}
+
+func (d tracedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+type tracedConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := startQuery(ctx, query)
+
+	res, err := c.SQLiteConn.ExecContext(ctx, query, args)
+	if err == nil {
+		if rows, rowsErr := res.RowsAffected(); rowsErr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", rows))
+		}
+	}
+
+	tracing.End(span, err)
+
+	return res, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	// the span lasts until the rows are read, so it covers the whole statement
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+// tracedRows counts the rows read and ends the span of the query on Close
+type tracedRows struct {
+	driver.Rows
+	span  trace.Span
+	count int64
+	err   error
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+
+	r.span.SetAttributes(attribute.Int64("db.rows_returned", r.count))
+	tracing.End(r.span, errors.Join(r.err, err))
+
+	return err
+}
+
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	// the queries outside of any trace, like the health pings, do not start traces of their own
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	query = strings.TrimSpace(query)
+
+	var operation string
+	if fields := strings.Fields(query); len(fields) != 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, "sqlite "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "sqlite"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", query),
+		),
+	)
+}
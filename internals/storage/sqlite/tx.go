@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// txState is the transaction bound to the context with the functions to run once it is committed
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func()
+}
+
+func txFromContext(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+
+	return state, ok
+}
+
+// conn returns the transaction bound to ctx by InTx or the database itself
+func (s *Storage) conn(ctx context.Context) executor {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return s.db
+}
+
+// AfterCommit runs fn once the transaction bound to ctx is committed, right away when there is none.
+// fn is dropped when the transaction or the savepoint it was registered in is rolled back.
+func (s *Storage) AfterCommit(ctx context.Context, fn func()) {
+	state, ok := txFromContext(ctx)
+	if !ok {
+		fn()
+		return
+	}
+
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// InTx runs fn in a transaction, every storage call made with the context passed to fn joins it.
+// The transaction is committed when fn succeeds and rolled back otherwise.
+// Nested calls join the already running transaction.
+func (s *Storage) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "storage.sqlite.InTx"
+
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("%s: %w", op, rbErr))
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, fn := range state.afterCommit {
+		fn()
+	}
+
+	return nil
+}
+
+// Savepoint runs fn inside a savepoint of the transaction bound to ctx.
+// When fn fails only its changes are rolled back and the transaction stays usable.
+func (s *Storage) Savepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	const op = "storage.sqlite.Savepoint"
+
+	state, ok := txFromContext(ctx)
+	if !ok {
+		return s.InTx(ctx, fn)
+	}
+	tx := state.tx
+
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT item`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	registered := len(state.afterCommit)
+	if err := fn(ctx); err != nil {
+		state.afterCommit = state.afterCommit[:registered]
+
+		if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO item`); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("%s: %w", op, rbErr))
+		}
+		if _, rlErr := tx.ExecContext(ctx, `RELEASE item`); rlErr != nil {
+			return errors.Join(err, fmt.Errorf("%s: %w", op, rlErr))
+		}
+
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `RELEASE item`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
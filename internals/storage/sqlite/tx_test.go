@@ -0,0 +1,62 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	pizzalndv1 "github.com/nhassl3/pizzaland/api/generated/go/pizzaland"
+	"github.com/nhassl3/pizzaland/internals/storage/sqlite/sqlitetest"
+)
+
+func TestInTxConcurrentWrites(t *testing.T) {
+	s := sqlitetest.New(t)
+
+	const (
+		workers = 8
+		writes  = 20
+	)
+
+	// every transaction reads before it writes, the deferred ones fail to upgrade their read locks
+	errs := make(chan error, workers*writes)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range writes {
+				errs <- s.InTx(context.Background(), func(ctx context.Context) error {
+					if _, err := s.ListCategories(ctx, 0, 10); err != nil {
+						return err
+					}
+
+					_, err := s.SaveCategory(ctx, &pizzalndv1.CategoryProperties{Name: fmt.Sprintf("Category %d-%d", w, i)})
+					return err
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+			t.Log(err)
+		}
+	}
+	if failed != 0 {
+		t.Fatalf("%d of %d transactions failed", failed, workers*writes)
+	}
+
+	categories, err := s.ListCategories(context.Background(), 0, workers*writes+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != workers*writes {
+		t.Errorf("%d categories saved, want %d", len(categories), workers*writes)
+	}
+}
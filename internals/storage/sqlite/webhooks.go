@@ -0,0 +1,381 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nhassl3/pizzaland/internals/domain/models"
+	"github.com/nhassl3/pizzaland/internals/storage"
+)
+
+const (
+	webhookColumns  = "id, url, event_types, secret, enabled, consecutive_failures, created_at, updated_at, disabled_at"
+	deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, " +
+		"response_code, last_error, created_at, completed_at"
+)
+
+// SaveWebhook stores the webhook, the event types are kept space separated
+func (s *Storage) SaveWebhook(ctx context.Context, hook models.Webhook) (id uint64, err error) {
+	const op = "storage.sqlite.SaveWebhook"
+
+	res, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO webhooks (url, event_types, secret, enabled, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		hook.URL, strings.Join(hook.EventTypes, " "), hook.Secret, hook.Enabled, hook.CreatedAt.UTC(), hook.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lastId, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uint64(lastId), nil
+}
+
+func (s *Storage) Webhook(ctx context.Context, id uint64) (hook models.Webhook, err error) {
+	const op = "storage.sqlite.Webhook"
+
+	hook, err = scanWebhook(s.conn(ctx).QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Webhook{}, fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+		}
+
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hook, nil
+}
+
+func (s *Storage) ListWebhooks(ctx context.Context, offset uint32, limit uint32) (hooks []models.Webhook, err error) {
+	const op = "storage.sqlite.ListWebhooks"
+
+	rows, err := s.conn(ctx).QueryContext(
+		ctx,
+		`SELECT `+webhookColumns+` FROM webhooks ORDER BY id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hooks, nil
+}
+
+// UpdateWebhook applies the update and returns the updated webhook.
+// Enabling the webhook resets its failures and makes its held back deliveries due at once.
+func (s *Storage) UpdateWebhook(ctx context.Context, id uint64, update models.WebhookUpdate, at time.Time) (hook models.Webhook, err error) {
+	const op = "storage.sqlite.UpdateWebhook"
+
+	err = s.InTx(ctx, func(ctx context.Context) error {
+		if hook, err = s.Webhook(ctx, id); err != nil {
+			return err
+		}
+
+		if update.URL != nil {
+			hook.URL = *update.URL
+		}
+		if len(update.EventTypes) != 0 {
+			hook.EventTypes = update.EventTypes
+		}
+		if update.Secret != nil {
+			hook.Secret = *update.Secret
+		}
+		if update.Enabled != nil && *update.Enabled != hook.Enabled {
+			hook.Enabled = *update.Enabled
+			hook.ConsecutiveFailures, hook.DisabledAt = 0, at
+			if hook.Enabled {
+				hook.DisabledAt = time.Time{}
+
+				if _, err := s.conn(ctx).ExecContext(
+					ctx,
+					`UPDATE webhook_deliveries SET next_attempt_at = ? WHERE webhook_id = ? AND status = ?`,
+					at.UTC(), id, models.DeliveryPending,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		hook.UpdatedAt = at
+
+		_, err := s.conn(ctx).ExecContext(
+			ctx,
+			`UPDATE webhooks SET url = ?, event_types = ?, secret = ?, enabled = ?, consecutive_failures = ?, disabled_at = ?, updated_at = ?
+			WHERE id = ?`,
+			hook.URL, strings.Join(hook.EventTypes, " "), hook.Secret, hook.Enabled, hook.ConsecutiveFailures,
+			nullTime(hook.DisabledAt), at.UTC(), id,
+		)
+
+		return err
+	})
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hook, nil
+}
+
+// DeleteWebhook removes the webhook together with its deliveries
+func (s *Storage) DeleteWebhook(ctx context.Context, id uint64) error {
+	const op = "storage.sqlite.DeleteWebhook"
+
+	err := s.InTx(ctx, func(ctx context.Context) error {
+		if _, err := s.conn(ctx).ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE webhook_id = ?`, id); err != nil {
+			return err
+		}
+
+		res, err := s.conn(ctx).ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return storage.ErrWebhookNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SaveWebhookDeliveries queues the event for every enabled webhook of its type, call it in the transaction of the change
+func (s *Storage) SaveWebhookDeliveries(ctx context.Context, event models.Event) error {
+	const op = "storage.sqlite.SaveWebhookDeliveries"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+		SELECT id, ?, ?, ?, ?, ? FROM webhooks WHERE enabled AND ' ' || event_types || ' ' LIKE ?`,
+		event.ID, event.Type, string(payload), event.Time.UTC(), event.Time.UTC(), "% "+event.Type+" %",
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries returns the deliveries of the webhook newest first, all the statuses when status is empty
+func (s *Storage) ListWebhookDeliveries(
+	ctx context.Context,
+	webhookId uint64,
+	status string,
+	offset uint32,
+	limit uint32,
+) (deliveries []models.WebhookDelivery, err error) {
+	const op = "storage.sqlite.ListWebhookDeliveries"
+
+	query, args := `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE webhook_id = ?`, []any{webhookId}
+	if status != "" {
+		query, args = query+` AND status = ?`, append(args, status)
+	}
+	query, args = query+` ORDER BY id DESC LIMIT ? OFFSET ?`, append(args, limit, offset)
+
+	if deliveries, err = s.queryDeliveries(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// DueWebhookDeliveries returns the pending deliveries of the enabled webhooks which are due at now in the order they were queued
+func (s *Storage) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []models.WebhookDelivery, err error) {
+	const op = "storage.sqlite.DueWebhookDeliveries"
+
+	deliveries, err = s.queryDeliveries(
+		ctx,
+		`SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ? AND webhook_id IN (SELECT id FROM webhooks WHERE enabled)
+		ORDER BY id LIMIT ?`,
+		models.DeliveryPending, now.UTC(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// CompleteWebhookDelivery records the last attempt of the delivery, status is delivered or failed
+func (s *Storage) CompleteWebhookDelivery(ctx context.Context, id uint64, status string, responseCode int, lastError string, at time.Time) error {
+	const op = "storage.sqlite.CompleteWebhookDelivery"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, response_code = ?, last_error = ?, completed_at = ?
+		WHERE id = ?`,
+		status, nullInt(responseCode), sql.NullString{String: lastError, Valid: lastError != ""}, at.UTC(), id,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RetryWebhookDelivery records the failed attempt of the delivery and postpones the next one
+func (s *Storage) RetryWebhookDelivery(ctx context.Context, id uint64, responseCode int, lastError string, nextAttemptAt time.Time) error {
+	const op = "storage.sqlite.RetryWebhookDelivery"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, response_code = ?, last_error = ?, next_attempt_at = ? WHERE id = ?`,
+		nullInt(responseCode), lastError, nextAttemptAt.UTC(), id,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PostponeWebhookDeliveries holds back the pending deliveries of the webhook due before until
+func (s *Storage) PostponeWebhookDeliveries(ctx context.Context, webhookId uint64, until time.Time) error {
+	const op = "storage.sqlite.PostponeWebhookDeliveries"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET next_attempt_at = ? WHERE webhook_id = ? AND status = ? AND next_attempt_at < ?`,
+		until.UTC(), webhookId, models.DeliveryPending, until.UTC(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetWebhookFailures records the successful delivery to the webhook
+func (s *Storage) ResetWebhookFailures(ctx context.Context, id uint64) error {
+	const op = "storage.sqlite.ResetWebhookFailures"
+
+	if _, err := s.conn(ctx).ExecContext(ctx, `UPDATE webhooks SET consecutive_failures = 0 WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FailWebhook records the failed delivery to the webhook and returns its failures in a row
+func (s *Storage) FailWebhook(ctx context.Context, id uint64) (failures int, err error) {
+	const op = "storage.sqlite.FailWebhook"
+
+	if err := s.conn(ctx).QueryRowContext(
+		ctx,
+		`UPDATE webhooks SET consecutive_failures = consecutive_failures + 1 WHERE id = ? RETURNING consecutive_failures`,
+		id,
+	).Scan(&failures); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failures, nil
+}
+
+// DisableWebhook stops the deliveries to the webhook, the pending ones are held until it is enabled again
+func (s *Storage) DisableWebhook(ctx context.Context, id uint64, at time.Time) error {
+	const op = "storage.sqlite.DisableWebhook"
+
+	if _, err := s.conn(ctx).ExecContext(
+		ctx,
+		`UPDATE webhooks SET enabled = FALSE, disabled_at = ?, updated_at = ? WHERE id = ?`,
+		at.UTC(), at.UTC(), id,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) queryDeliveries(ctx context.Context, query string, args ...any) (deliveries []models.WebhookDelivery, err error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			delivery     models.WebhookDelivery
+			responseCode sql.NullInt64
+			lastError    sql.NullString
+			completedAt  sql.NullTime
+		)
+		if err := rows.Scan(
+			&delivery.ID, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+			&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &responseCode, &lastError,
+			&delivery.CreatedAt, &completedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		delivery.ResponseCode, delivery.LastError = int(responseCode.Int64), lastError.String
+		delivery.CompletedAt = completedAt.Time
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row scanner) (models.Webhook, error) {
+	var (
+		hook       models.Webhook
+		eventTypes string
+		disabledAt sql.NullTime
+	)
+
+	if err := row.Scan(
+		&hook.ID, &hook.URL, &eventTypes, &hook.Secret, &hook.Enabled, &hook.ConsecutiveFailures,
+		&hook.CreatedAt, &hook.UpdatedAt, &disabledAt,
+	); err != nil {
+		return models.Webhook{}, err
+	}
+
+	hook.EventTypes, hook.DisabledAt = strings.Fields(eventTypes), disabledAt.Time
+
+	return hook, nil
+}
+
+// nullInt stores zero as NULL
+func nullInt(n int) any {
+	if n == 0 {
+		return nil
+	}
+
+	return n
+}
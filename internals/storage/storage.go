@@ -3,8 +3,20 @@ package storage
 import "errors"
 
 var (
+	// ErrNotMigrated is the database without the schema, e.g. the file was removed or never migrated
+	ErrNotMigrated = errors.New("database is not migrated")
+
 	ErrPizzaExists      = errors.New("pizza already exists")
 	ErrPizzaNotFound    = errors.New("pizza not found")
 	ErrCategoryExists   = errors.New("category already exists")
 	ErrCategoryNotFound = errors.New("category not found")
+	ErrVersionMismatch  = errors.New("version mismatch")
+
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+	ErrAPIKeyExists   = errors.New("api key already exists")
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	ErrWebhookNotFound = errors.New("webhook not found")
 )
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+ALTER TABLE categories DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_pizza_deleted_at;
+ALTER TABLE pizza DROP COLUMN deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE pizza ADD COLUMN deleted_at DATETIME;
+CREATE INDEX IF NOT EXISTS idx_pizza_deleted_at ON pizza(deleted_at);
+
+ALTER TABLE categories ADD COLUMN deleted_at DATETIME;
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories(deleted_at);
@@ -0,0 +1,3 @@
+ALTER TABLE categories DROP COLUMN version;
+
+ALTER TABLE pizza DROP COLUMN version;
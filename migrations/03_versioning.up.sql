@@ -0,0 +1,3 @@
+ALTER TABLE pizza ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE categories ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
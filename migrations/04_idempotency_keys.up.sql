@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                                scope VARCHAR(64) NOT NULL,
+                                                key VARCHAR(255) NOT NULL,
+                                                request_hash BLOB NOT NULL,
+                                                response BLOB NOT NULL,
+                                                created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                                expires_at INTEGER NOT NULL,
+                                                PRIMARY KEY (scope, key)
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
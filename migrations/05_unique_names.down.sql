@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_categories_name_key;
+ALTER TABLE categories DROP COLUMN name_key;
+
+DROP INDEX IF EXISTS idx_pizza_name_key;
+ALTER TABLE pizza DROP COLUMN name_key;
+CREATE INDEX IF NOT EXISTS idx_pizza_name ON pizza(name);
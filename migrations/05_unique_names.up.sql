@@ -0,0 +1,9 @@
+-- name_key(name) is registered by the application sqlite driver, run the migrator shipped with the service
+ALTER TABLE pizza ADD COLUMN name_key VARCHAR(50);
+UPDATE pizza SET name_key = name_key(name);
+DROP INDEX IF EXISTS idx_pizza_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pizza_name_key ON pizza(name_key COLLATE NOCASE) WHERE deleted_at IS NULL;
+
+ALTER TABLE categories ADD COLUMN name_key VARCHAR(26);
+UPDATE categories SET name_key = name_key(name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_key ON categories(name_key COLLATE NOCASE) WHERE deleted_at IS NULL;
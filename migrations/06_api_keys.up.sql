@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+                                        id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                        prefix VARCHAR(16) NOT NULL UNIQUE,
+                                        secret_hash VARCHAR(128) NOT NULL,
+                                        label VARCHAR(64) NOT NULL,
+                                        scopes TEXT NOT NULL,
+                                        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                        expires_at DATETIME,
+                                        last_used_at DATETIME,
+                                        revoked_at DATETIME
+);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+                                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                         occurred_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                         actor VARCHAR(255) NOT NULL,
+                                         request_id VARCHAR(128) NOT NULL,
+                                         operation VARCHAR(16) NOT NULL,
+                                         entity VARCHAR(16) NOT NULL,
+                                         entity_id INTEGER NOT NULL,
+                                         before TEXT,
+                                         after TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor, occurred_at);
+
+-- the log is append-only, the entries cannot be changed or deleted
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
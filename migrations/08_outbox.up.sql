@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS outbox (
+                                      id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                      event_id VARCHAR(36) NOT NULL UNIQUE,
+                                      type VARCHAR(64) NOT NULL,
+                                      source VARCHAR(64) NOT NULL,
+                                      subject VARCHAR(64) NOT NULL,
+                                      time DATETIME NOT NULL,
+                                      data TEXT NOT NULL,
+                                      attempts INTEGER NOT NULL DEFAULT 0,
+                                      next_attempt_at DATETIME NOT NULL,
+                                      last_error TEXT
+);
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+                                            id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                            event_id VARCHAR(36) NOT NULL UNIQUE,
+                                            type VARCHAR(64) NOT NULL,
+                                            source VARCHAR(64) NOT NULL,
+                                            subject VARCHAR(64) NOT NULL,
+                                            time DATETIME NOT NULL,
+                                            data TEXT NOT NULL,
+                                            attempts INTEGER NOT NULL,
+                                            last_error TEXT,
+                                            failed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
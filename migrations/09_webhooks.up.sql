@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+                                        id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                        url VARCHAR(2048) NOT NULL,
+                                        event_types TEXT NOT NULL,
+                                        secret VARCHAR(128) NOT NULL,
+                                        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+                                        consecutive_failures INTEGER NOT NULL DEFAULT 0,
+                                        disabled_at DATETIME,
+                                        created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                        updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                                  id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                                  webhook_id INTEGER NOT NULL,
+                                                  event_id VARCHAR(36) NOT NULL,
+                                                  event_type VARCHAR(64) NOT NULL,
+                                                  payload TEXT NOT NULL,
+                                                  status VARCHAR(16) NOT NULL DEFAULT 'pending',
+                                                  attempts INTEGER NOT NULL DEFAULT 0,
+                                                  next_attempt_at DATETIME NOT NULL,
+                                                  response_code INTEGER,
+                                                  last_error TEXT,
+                                                  created_at DATETIME NOT NULL,
+                                                  completed_at DATETIME,
+                                                  FOREIGN KEY (webhook_id) REFERENCES webhooks(id),
+                                                  UNIQUE (webhook_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pizza_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pizza_name_key ON pizza(name_key COLLATE NOCASE) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_categories_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_key ON categories(name_key COLLATE NOCASE) WHERE deleted_at IS NULL;
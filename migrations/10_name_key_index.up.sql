@@ -0,0 +1,6 @@
+-- name_key is folded by names.Key, so the lookups compare it with the BINARY collation and the indexes must use it too
+DROP INDEX IF EXISTS idx_pizza_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pizza_name_key ON pizza(name_key) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_categories_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_key ON categories(name_key) WHERE deleted_at IS NULL;